@@ -0,0 +1,413 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/Mungge/Fleecy-Cloud/models"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	agentInstallDir     = "/opt/fleecy-agent"
+	agentManifestPath   = agentInstallDir + "/job.json"
+	agentStatusPath     = agentInstallDir + "/status.json"
+	agentCancelFlagPath = agentInstallDir + "/cancel"
+	agentArtifactsDir   = agentInstallDir + "/artifacts"
+	agentSystemdUnit    = "fleecy-agent.service"
+	agentSSHUser        = "ubuntu"
+	agentSSHTimeout     = 15 * time.Second
+)
+
+// TrainingJobManifest는 VM의 Fleecy 에이전트에게 전달되는 연합학습 작업 사양입니다.
+type TrainingJobManifest struct {
+	TaskID           string
+	RoundID          int
+	DatasetRef       string
+	ModelArtifactURL string
+	Hyperparams      map[string]interface{}
+}
+
+// BuildAgentCloudInit는 VM 부팅 시 Fleecy 에이전트를 설치하고 systemd 서비스로
+// 등록하는 cloud-init user_data를 생성합니다. callbackURL은 에이전트가 학습 로그를
+// 스트리밍해 보낼 HTTP 엔드포인트입니다.
+func BuildAgentCloudInit(callbackURL string) string {
+	return fmt.Sprintf(`#cloud-config
+package_update: true
+packages:
+  - python3
+write_files:
+  - path: %[1]s/agent.py
+    permissions: '0755'
+    content: |
+      #!/usr/bin/env python3
+      # 할당된 작업(job.json)을 읽어 학습을 실행하고, 진행 상황을 status.json에
+      # 기록하는 한편 콜백 URL로도 스트리밍합니다. cancel 플래그 파일이 생기면
+      # 학습을 중단하고 cancelled 상태로 종료합니다.
+      import json
+      import os
+      import time
+      import urllib.request
+
+      CALLBACK_URL = "%[2]s"
+      MANIFEST_PATH = "%[3]s"
+      STATUS_PATH = "%[5]s"
+      CANCEL_FLAG_PATH = "%[6]s"
+      ARTIFACTS_DIR = "%[7]s"
+      TOTAL_STEPS = 10
+      STEP_INTERVAL_SECONDS = 5
+
+      def notify(task_id, status, **fields):
+          body = json.dumps({"task_id": task_id, "status": status, **fields}).encode()
+          req = urllib.request.Request(CALLBACK_URL, data=body, headers={"Content-Type": "application/json"})
+          urllib.request.urlopen(req)
+
+      def write_status(task_id, state, progress, message=""):
+          payload = {
+              "task_id": task_id,
+              "state": state,
+              "progress": progress,
+              "message": message,
+              "updated_at": time.time(),
+          }
+          tmp_path = STATUS_PATH + ".tmp"
+          with open(tmp_path, "w") as f:
+              json.dump(payload, f)
+          os.replace(tmp_path, STATUS_PATH)
+
+      def main():
+          with open(MANIFEST_PATH) as f:
+              job = json.load(f)
+          task_id = job["task_id"]
+
+          write_status(task_id, "running", 0.0, "학습 시작")
+          notify(task_id, "started", round_id=job["round_id"])
+
+          try:
+              os.makedirs(ARTIFACTS_DIR, exist_ok=True)
+              for step in range(1, TOTAL_STEPS + 1):
+                  if os.path.exists(CANCEL_FLAG_PATH):
+                      write_status(task_id, "cancelled", step / TOTAL_STEPS, "취소 요청으로 중단됨")
+                      notify(task_id, "cancelled")
+                      return
+
+                  time.sleep(STEP_INTERVAL_SECONDS)
+                  progress = step / TOTAL_STEPS
+                  write_status(task_id, "running", progress, "라운드 %%d/%%d 학습 중" %% (job["round_id"], step))
+                  notify(task_id, "progress", progress=progress)
+
+              artifact_path = os.path.join(ARTIFACTS_DIR, "round-%%d.json" %% job["round_id"])
+              with open(artifact_path, "w") as f:
+                  json.dump({"task_id": task_id, "round_id": job["round_id"]}, f)
+
+              write_status(task_id, "completed", 1.0, "학습 완료")
+              notify(task_id, "completed")
+          except Exception as exc:
+              write_status(task_id, "failed", 0.0, str(exc))
+              notify(task_id, "failed", message=str(exc))
+              raise
+
+      if __name__ == "__main__":
+          main()
+  - path: /etc/systemd/system/%[4]s
+    permissions: '0644'
+    content: |
+      [Unit]
+      Description=Fleecy federated-learning agent
+      After=network-online.target
+
+      [Service]
+      ExecStart=/usr/bin/python3 %[1]s/agent.py
+      Restart=on-failure
+      WorkingDirectory=%[1]s
+
+      [Install]
+      WantedBy=multi-user.target
+runcmd:
+  - mkdir -p %[1]s %[7]s
+  - systemctl daemon-reload
+  - systemctl enable %[4]s
+`, agentInstallDir, callbackURL, agentManifestPath, agentSystemdUnit, agentStatusPath, agentCancelFlagPath, agentArtifactsDir)
+}
+
+// NewFederatedLearningVMSpec은 Fleecy 에이전트 부트스트랩 cloud-init이 포함된
+// VMCreateSpec을 생성합니다.
+func NewFederatedLearningVMSpec(name, imageID, flavorID, network, keyName, callbackURL string) VMCreateSpec {
+	return VMCreateSpec{
+		Name:     name,
+		ImageID:  imageID,
+		FlavorID: flavorID,
+		Network:  network,
+		KeyName:  keyName,
+		UserData: BuildAgentCloudInit(callbackURL),
+	}
+}
+
+// floatingVMAddress는 VMInstance.Addresses에서 floating IP를 찾습니다.
+func floatingVMAddress(instance *VMInstance) (string, error) {
+	for _, addresses := range instance.Addresses {
+		for _, addr := range addresses {
+			if addr.Type == "floating" {
+				return addr.Addr, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("floating IP를 찾을 수 없습니다: %s", instance.ID)
+}
+
+// sshDialParticipant는 participant에 등록된 SSH 키페어로 floating IP에 연결합니다.
+// 호스트 키는 hostKeys에 pinning되어, 최초 접속 이후 동일 instanceID에 대해 다른
+// 호스트 키가 제시되면(경로상 공격 등) 접속을 거부합니다.
+func (s *OpenStackService) sshDialParticipant(participant *models.Participant, instanceID, host string) (*ssh.Client, error) {
+	signer, err := ssh.ParsePrivateKey([]byte(participant.SSHPrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("SSH 개인키 파싱 실패: %v", err)
+	}
+
+	config := &ssh.ClientConfig{
+		User:            agentSSHUser,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: s.hostKeys.callback(instanceID),
+		Timeout:         agentSSHTimeout,
+	}
+
+	client, err := ssh.Dial("tcp", net.JoinHostPort(host, "22"), config)
+	if err != nil {
+		return nil, fmt.Errorf("SSH 연결 실패: %v", err)
+	}
+
+	return client, nil
+}
+
+// runSSHCommand는 새 세션을 열어 명령을 실행합니다.
+func runSSHCommand(client *ssh.Client, command string) error {
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("SSH 세션 생성 실패: %v", err)
+	}
+	defer session.Close()
+
+	var stderr bytes.Buffer
+	session.Stderr = &stderr
+
+	if err := session.Run(command); err != nil {
+		return fmt.Errorf("명령 실행 실패(%s): %v, stderr: %s", command, err, stderr.String())
+	}
+
+	return nil
+}
+
+// runSSHCommandOutput는 새 세션을 열어 명령을 실행하고 표준 출력을 반환합니다.
+func runSSHCommandOutput(client *ssh.Client, command string) (string, error) {
+	session, err := client.NewSession()
+	if err != nil {
+		return "", fmt.Errorf("SSH 세션 생성 실패: %v", err)
+	}
+	defer session.Close()
+
+	var stdout, stderr bytes.Buffer
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+
+	if err := session.Run(command); err != nil {
+		return "", fmt.Errorf("명령 실행 실패(%s): %v, stderr: %s", command, err, stderr.String())
+	}
+
+	return stdout.String(), nil
+}
+
+// uploadManifest는 작업 매니페스트를 VM의 agentManifestPath 경로에 기록합니다.
+func uploadManifest(client *ssh.Client, manifest TrainingJobManifest) error {
+	payload, err := json.Marshal(struct {
+		TaskID           string                 `json:"task_id"`
+		RoundID          int                    `json:"round_id"`
+		DatasetRef       string                 `json:"dataset_ref"`
+		ModelArtifactURL string                 `json:"model_artifact_url"`
+		Hyperparams      map[string]interface{} `json:"hyperparams"`
+	}{
+		TaskID:           manifest.TaskID,
+		RoundID:          manifest.RoundID,
+		DatasetRef:       manifest.DatasetRef,
+		ModelArtifactURL: manifest.ModelArtifactURL,
+		Hyperparams:      manifest.Hyperparams,
+	})
+	if err != nil {
+		return fmt.Errorf("작업 매니페스트 직렬화 실패: %v", err)
+	}
+
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("SSH 세션 생성 실패: %v", err)
+	}
+	defer session.Close()
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("SSH stdin 파이프 생성 실패: %v", err)
+	}
+
+	if err := session.Start(fmt.Sprintf("sudo mkdir -p %s && sudo tee %s > /dev/null", agentInstallDir, agentManifestPath)); err != nil {
+		return fmt.Errorf("매니페스트 업로드 명령 실행 실패: %v", err)
+	}
+
+	if _, err := stdin.Write(payload); err != nil {
+		return fmt.Errorf("매니페스트 전송 실패: %v", err)
+	}
+	stdin.Close()
+
+	if err := session.Wait(); err != nil {
+		return fmt.Errorf("매니페스트 업로드 실패: %v", err)
+	}
+
+	return nil
+}
+
+// dialAgentVM은 vm이 ACTIVE 상태인지 확인한 뒤 floating IP로 SSH 연결을 맺습니다.
+// AssignFederatedLearningTask와 task 상태 조회/취소/산출물 조회 메서드들이 공통으로
+// 사용하는 진입점입니다.
+func (s *OpenStackService) dialAgentVM(participant *models.Participant, vm *models.VirtualMachine) (*ssh.Client, error) {
+	instance, err := s.GetVMInstance(vm, participant)
+	if err != nil {
+		return nil, fmt.Errorf("VM 상태 확인 실패: %v", err)
+	}
+
+	if instance.Status != "ACTIVE" {
+		return nil, fmt.Errorf("VM이 활성 상태가 아닙니다: %s", instance.Status)
+	}
+
+	host, err := floatingVMAddress(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.sshDialParticipant(participant, instance.ID, host)
+}
+
+// AssignFederatedLearningTask는 실행 중인 VM에 SSH로 접속해 작업 매니페스트를
+// 전달하고 Fleecy 에이전트를 systemd 서비스로 (재)기동합니다. VM 생성 시 이미
+// cloud-init(BuildAgentCloudInit)으로 에이전트가 설치되어 있어야 합니다.
+func (s *OpenStackService) AssignFederatedLearningTask(participant *models.Participant, vm *models.VirtualMachine, manifest TrainingJobManifest) error {
+	client, err := s.dialAgentVM(participant, vm)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := uploadManifest(client, manifest); err != nil {
+		return err
+	}
+
+	// 이전 작업에 대한 취소 플래그가 남아 있으면 새 작업이 곧바로 취소되므로 먼저 지웁니다.
+	if err := runSSHCommand(client, fmt.Sprintf("sudo rm -f %s && sudo systemctl restart %s", agentCancelFlagPath, agentSystemdUnit)); err != nil {
+		return fmt.Errorf("에이전트 기동 실패: %v", err)
+	}
+
+	return nil
+}
+
+// TaskStatus는 VM에서 실행 중인 Fleecy 에이전트가 agentStatusPath에 기록하는
+// 작업 진행 상태입니다.
+type TaskStatus struct {
+	TaskID    string    `json:"task_id"`
+	State     string    `json:"state"`
+	Progress  float64   `json:"progress"`
+	Message   string    `json:"message"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+// readTaskStatus는 이미 연결된 SSH 세션을 통해 에이전트가 기록한 status.json을
+// 읽어옵니다. taskID를 검증하지 않으므로 호출부가 직접 확인해야 합니다.
+func readTaskStatus(client *ssh.Client) (*TaskStatus, error) {
+	output, err := runSSHCommandOutput(client, fmt.Sprintf("cat %s", agentStatusPath))
+	if err != nil {
+		return nil, fmt.Errorf("작업 상태 조회 실패: %v", err)
+	}
+
+	var wire struct {
+		TaskID    string  `json:"task_id"`
+		State     string  `json:"state"`
+		Progress  float64 `json:"progress"`
+		Message   string  `json:"message"`
+		UpdatedAt float64 `json:"updated_at"`
+	}
+	if err := json.Unmarshal([]byte(output), &wire); err != nil {
+		return nil, fmt.Errorf("작업 상태 파싱 실패: %v", err)
+	}
+
+	return &TaskStatus{
+		TaskID:    wire.TaskID,
+		State:     wire.State,
+		Progress:  wire.Progress,
+		Message:   wire.Message,
+		UpdatedAt: time.Unix(int64(wire.UpdatedAt), 0),
+	}, nil
+}
+
+// GetTaskStatus는 VM에 SSH로 접속해 에이전트가 기록한 status.json을 읽어 taskID에
+// 대한 진행 상태를 반환합니다.
+func (s *OpenStackService) GetTaskStatus(participant *models.Participant, vm *models.VirtualMachine, taskID string) (*TaskStatus, error) {
+	client, err := s.dialAgentVM(participant, vm)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	status, err := readTaskStatus(client)
+	if err != nil {
+		return nil, err
+	}
+
+	if status.TaskID != taskID {
+		return nil, fmt.Errorf("에이전트가 보고하는 작업(%s)이 요청한 작업(%s)과 다릅니다", status.TaskID, taskID)
+	}
+
+	return status, nil
+}
+
+// CancelTask는 VM에 SSH로 접속해 agentCancelFlagPath에 플래그 파일을 만듭니다.
+// 에이전트는 다음 학습 스텝에서 이 플래그를 확인해 cancelled 상태로 정리하고
+// 종료합니다. status.json에 기록된 작업이 taskID와 다르면(예: 이미 새 작업이
+// 재할당된 경우) 엉뚱한 작업을 취소하지 않도록 거부합니다.
+func (s *OpenStackService) CancelTask(participant *models.Participant, vm *models.VirtualMachine, taskID string) error {
+	client, err := s.dialAgentVM(participant, vm)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	status, err := readTaskStatus(client)
+	if err != nil {
+		return err
+	}
+
+	if status.TaskID != taskID {
+		return fmt.Errorf("에이전트가 보고하는 작업(%s)이 요청한 작업(%s)과 다릅니다", status.TaskID, taskID)
+	}
+
+	if err := runSSHCommand(client, fmt.Sprintf("sudo touch %s", agentCancelFlagPath)); err != nil {
+		return fmt.Errorf("작업 취소 요청 실패: %v", err)
+	}
+
+	return nil
+}
+
+// FetchTaskArtifacts는 VM에 SSH로 접속해 agentArtifactsDir 아래에 기록된 학습
+// 산출물을 tar.gz로 묶어 바이트로 반환합니다.
+func (s *OpenStackService) FetchTaskArtifacts(participant *models.Participant, vm *models.VirtualMachine, taskID string) ([]byte, error) {
+	client, err := s.dialAgentVM(participant, vm)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	output, err := runSSHCommandOutput(client, fmt.Sprintf("tar -C %s -czf - .", agentArtifactsDir))
+	if err != nil {
+		return nil, fmt.Errorf("작업 산출물 조회 실패: %v", err)
+	}
+
+	return []byte(output), nil
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/Mungge/Fleecy-Cloud/models"
+)
+
+// 아래 드라이버들은 CloudProvider 인터페이스에 AWS EC2, GCP Compute, Azure,
+// 그리고 이미 프로비저닝된 노드를 그대로 참여자로 등록하는 "kubernetes-node"
+// provider type 자리를 마련해 둔 스캐폴드입니다. 실제 API 연동은 후속 작업에서
+// 채워집니다.
+
+// AWSProvider는 AWS EC2를 대상으로 하는 CloudProvider 구현체입니다.
+type AWSProvider struct{}
+
+// NewAWSProvider는 AWSProvider를 생성합니다.
+func NewAWSProvider() *AWSProvider {
+	return &AWSProvider{}
+}
+
+func (p *AWSProvider) Authenticate(participant *models.Participant) error {
+	return fmt.Errorf("AWS provider는 아직 구현되지 않았습니다")
+}
+
+func (p *AWSProvider) ListVMs(participant *models.Participant) ([]VMInstance, error) {
+	return nil, fmt.Errorf("AWS provider는 아직 구현되지 않았습니다")
+}
+
+func (p *AWSProvider) GetVM(participant *models.Participant, instanceID string) (*VMInstance, error) {
+	return nil, fmt.Errorf("AWS provider는 아직 구현되지 않았습니다")
+}
+
+func (p *AWSProvider) CreateVM(participant *models.Participant, spec VMCreateSpec) (*VMInstance, error) {
+	return nil, fmt.Errorf("AWS provider는 아직 구현되지 않았습니다")
+}
+
+func (p *AWSProvider) DeleteVM(participant *models.Participant, instanceID string) error {
+	return fmt.Errorf("AWS provider는 아직 구현되지 않았습니다")
+}
+
+func (p *AWSProvider) HealthCheck(participant *models.Participant, instanceID string) (*VMHealthCheckResult, error) {
+	return nil, fmt.Errorf("AWS provider는 아직 구현되지 않았습니다")
+}
+
+func (p *AWSProvider) AssignTask(participant *models.Participant, instanceID string, taskID string) error {
+	return fmt.Errorf("AWS provider는 아직 구현되지 않았습니다")
+}
+
+// GCPProvider는 GCP Compute Engine을 대상으로 하는 CloudProvider 구현체입니다.
+type GCPProvider struct{}
+
+// NewGCPProvider는 GCPProvider를 생성합니다.
+func NewGCPProvider() *GCPProvider {
+	return &GCPProvider{}
+}
+
+func (p *GCPProvider) Authenticate(participant *models.Participant) error {
+	return fmt.Errorf("GCP provider는 아직 구현되지 않았습니다")
+}
+
+func (p *GCPProvider) ListVMs(participant *models.Participant) ([]VMInstance, error) {
+	return nil, fmt.Errorf("GCP provider는 아직 구현되지 않았습니다")
+}
+
+func (p *GCPProvider) GetVM(participant *models.Participant, instanceID string) (*VMInstance, error) {
+	return nil, fmt.Errorf("GCP provider는 아직 구현되지 않았습니다")
+}
+
+func (p *GCPProvider) CreateVM(participant *models.Participant, spec VMCreateSpec) (*VMInstance, error) {
+	return nil, fmt.Errorf("GCP provider는 아직 구현되지 않았습니다")
+}
+
+func (p *GCPProvider) DeleteVM(participant *models.Participant, instanceID string) error {
+	return fmt.Errorf("GCP provider는 아직 구현되지 않았습니다")
+}
+
+func (p *GCPProvider) HealthCheck(participant *models.Participant, instanceID string) (*VMHealthCheckResult, error) {
+	return nil, fmt.Errorf("GCP provider는 아직 구현되지 않았습니다")
+}
+
+func (p *GCPProvider) AssignTask(participant *models.Participant, instanceID string, taskID string) error {
+	return fmt.Errorf("GCP provider는 아직 구현되지 않았습니다")
+}
+
+// AzureProvider는 Azure Virtual Machines를 대상으로 하는 CloudProvider 구현체입니다.
+type AzureProvider struct{}
+
+// NewAzureProvider는 AzureProvider를 생성합니다.
+func NewAzureProvider() *AzureProvider {
+	return &AzureProvider{}
+}
+
+func (p *AzureProvider) Authenticate(participant *models.Participant) error {
+	return fmt.Errorf("Azure provider는 아직 구현되지 않았습니다")
+}
+
+func (p *AzureProvider) ListVMs(participant *models.Participant) ([]VMInstance, error) {
+	return nil, fmt.Errorf("Azure provider는 아직 구현되지 않았습니다")
+}
+
+func (p *AzureProvider) GetVM(participant *models.Participant, instanceID string) (*VMInstance, error) {
+	return nil, fmt.Errorf("Azure provider는 아직 구현되지 않았습니다")
+}
+
+func (p *AzureProvider) CreateVM(participant *models.Participant, spec VMCreateSpec) (*VMInstance, error) {
+	return nil, fmt.Errorf("Azure provider는 아직 구현되지 않았습니다")
+}
+
+func (p *AzureProvider) DeleteVM(participant *models.Participant, instanceID string) error {
+	return fmt.Errorf("Azure provider는 아직 구현되지 않았습니다")
+}
+
+func (p *AzureProvider) HealthCheck(participant *models.Participant, instanceID string) (*VMHealthCheckResult, error) {
+	return nil, fmt.Errorf("Azure provider는 아직 구현되지 않았습니다")
+}
+
+func (p *AzureProvider) AssignTask(participant *models.Participant, instanceID string, taskID string) error {
+	return fmt.Errorf("Azure provider는 아직 구현되지 않았습니다")
+}
+
+// KubernetesNodeProvider는 클라우드 VM이 아니라 참여자가 직접 제공하는
+// Kubernetes 노드를 federated-learning worker로 등록하는 provider입니다.
+// VM 생명주기를 관리하지 않으므로 CreateVM/DeleteVM은 지원하지 않습니다.
+type KubernetesNodeProvider struct{}
+
+// NewKubernetesNodeProvider는 KubernetesNodeProvider를 생성합니다.
+func NewKubernetesNodeProvider() *KubernetesNodeProvider {
+	return &KubernetesNodeProvider{}
+}
+
+func (p *KubernetesNodeProvider) Authenticate(participant *models.Participant) error {
+	return fmt.Errorf("kubernetes-node provider는 아직 구현되지 않았습니다")
+}
+
+func (p *KubernetesNodeProvider) ListVMs(participant *models.Participant) ([]VMInstance, error) {
+	return nil, fmt.Errorf("kubernetes-node provider는 아직 구현되지 않았습니다")
+}
+
+func (p *KubernetesNodeProvider) GetVM(participant *models.Participant, instanceID string) (*VMInstance, error) {
+	return nil, fmt.Errorf("kubernetes-node provider는 아직 구현되지 않았습니다")
+}
+
+func (p *KubernetesNodeProvider) CreateVM(participant *models.Participant, spec VMCreateSpec) (*VMInstance, error) {
+	return nil, fmt.Errorf("kubernetes-node provider는 노드를 생성하지 않습니다")
+}
+
+func (p *KubernetesNodeProvider) DeleteVM(participant *models.Participant, instanceID string) error {
+	return fmt.Errorf("kubernetes-node provider는 노드를 삭제하지 않습니다")
+}
+
+func (p *KubernetesNodeProvider) HealthCheck(participant *models.Participant, instanceID string) (*VMHealthCheckResult, error) {
+	return nil, fmt.Errorf("kubernetes-node provider는 아직 구현되지 않았습니다")
+}
+
+func (p *KubernetesNodeProvider) AssignTask(participant *models.Participant, instanceID string, taskID string) error {
+	return fmt.Errorf("kubernetes-node provider는 아직 구현되지 않았습니다")
+}
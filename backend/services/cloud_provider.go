@@ -0,0 +1,178 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/Mungge/Fleecy-Cloud/models"
+)
+
+// VMCreateSpec은 CloudProvider.CreateVM 호출 시 필요한 VM 생성 스펙입니다.
+type VMCreateSpec struct {
+	Name     string
+	ImageID  string
+	FlavorID string
+	Network  string
+	KeyName  string
+	// UserData는 cloud-init 등 부트스트랩 스크립트입니다.
+	UserData string
+}
+
+// CloudProvider는 서로 다른 클라우드 플랫폼에 대해 동일한 방식으로 VM을
+// 조회/생성/삭제하고 연합학습 작업을 할당하기 위한 공통 인터페이스입니다.
+// models.Participant.ProviderType 값에 따라 registry에서 알맞은 구현체를 선택합니다.
+type CloudProvider interface {
+	// Authenticate는 participant 자격 증명으로 인증을 수행합니다.
+	Authenticate(participant *models.Participant) error
+	// ListVMs는 participant가 보유한 모든 VM 인스턴스를 조회합니다.
+	ListVMs(participant *models.Participant) ([]VMInstance, error)
+	// GetVM은 특정 VM 인스턴스의 현재 정보를 조회합니다.
+	GetVM(participant *models.Participant, instanceID string) (*VMInstance, error)
+	// CreateVM은 새로운 VM 인스턴스를 생성합니다.
+	CreateVM(participant *models.Participant, spec VMCreateSpec) (*VMInstance, error)
+	// DeleteVM은 VM 인스턴스를 삭제합니다.
+	DeleteVM(participant *models.Participant, instanceID string) error
+	// HealthCheck는 VM 인스턴스의 헬스 상태를 점검합니다.
+	HealthCheck(participant *models.Participant, instanceID string) (*VMHealthCheckResult, error)
+	// AssignTask는 연합학습 작업을 VM 인스턴스에 할당합니다.
+	AssignTask(participant *models.Participant, instanceID string, taskID string) error
+}
+
+// providerFactory는 ProviderType에 대응하는 CloudProvider를 생성합니다.
+type providerFactory func() CloudProvider
+
+var (
+	providerRegistryMu sync.RWMutex
+	providerRegistry   = map[string]providerFactory{}
+)
+
+// RegisterCloudProvider는 주어진 providerType에 대한 CloudProvider 생성 함수를
+// registry에 등록합니다. 기본 드라이버들은 이 파일의 init()에서 등록됩니다.
+func RegisterCloudProvider(providerType string, factory providerFactory) {
+	providerRegistryMu.Lock()
+	defer providerRegistryMu.Unlock()
+	providerRegistry[providerType] = factory
+}
+
+// GetCloudProvider는 models.Participant.ProviderType에 등록된 CloudProvider
+// 인스턴스를 반환합니다.
+func GetCloudProvider(providerType string) (CloudProvider, error) {
+	providerRegistryMu.RLock()
+	factory, ok := providerRegistry[providerType]
+	providerRegistryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("등록되지 않은 provider type입니다: %s", providerType)
+	}
+
+	return factory(), nil
+}
+
+func init() {
+	RegisterCloudProvider("openstack", func() CloudProvider {
+		return NewOpenStackProvider(NewOpenStackService())
+	})
+	RegisterCloudProvider("aws", func() CloudProvider {
+		return NewAWSProvider()
+	})
+	RegisterCloudProvider("gcp", func() CloudProvider {
+		return NewGCPProvider()
+	})
+	RegisterCloudProvider("azure", func() CloudProvider {
+		return NewAzureProvider()
+	})
+	RegisterCloudProvider("kubernetes-node", func() CloudProvider {
+		return NewKubernetesNodeProvider()
+	})
+}
+
+// OpenStackProvider는 OpenStackService를 CloudProvider 인터페이스에 맞게 감쌉니다.
+type OpenStackProvider struct {
+	service *OpenStackService
+}
+
+// NewOpenStackProvider는 주어진 OpenStackService를 사용하는 OpenStackProvider를
+// 생성합니다.
+func NewOpenStackProvider(service *OpenStackService) *OpenStackProvider {
+	return &OpenStackProvider{service: service}
+}
+
+func (p *OpenStackProvider) Authenticate(participant *models.Participant) error {
+	_, err := p.service.GetAuthToken(participant)
+	return err
+}
+
+func (p *OpenStackProvider) ListVMs(participant *models.Participant) ([]VMInstance, error) {
+	instances, _, err := p.service.GetAllVMInstances(participant)
+	return instances, err
+}
+
+func (p *OpenStackProvider) GetVM(participant *models.Participant, instanceID string) (*VMInstance, error) {
+	return p.service.GetVMInstance(&models.VirtualMachine{InstanceID: instanceID}, participant)
+}
+
+func (p *OpenStackProvider) CreateVM(participant *models.Participant, spec VMCreateSpec) (*VMInstance, error) {
+	return p.service.CreateVMInstance(participant, spec)
+}
+
+func (p *OpenStackProvider) DeleteVM(participant *models.Participant, instanceID string) error {
+	return p.service.RemoveVMInstance(participant, instanceID)
+}
+
+func (p *OpenStackProvider) HealthCheck(participant *models.Participant, instanceID string) (*VMHealthCheckResult, error) {
+	return p.service.HealthCheckSpecificVM(participant, &models.VirtualMachine{InstanceID: instanceID})
+}
+
+func (p *OpenStackProvider) AssignTask(participant *models.Participant, instanceID string, taskID string) error {
+	return p.service.AssignFederatedLearningTaskSpecific(participant, &models.VirtualMachine{InstanceID: instanceID}, taskID)
+}
+
+// SyncVMsForParticipant는 participant.ProviderType에 등록된 CloudProvider를 통해
+// VM 목록을 동기화합니다. 핸들러나 동기화 작업은 특정 클라우드 구현체
+// (OpenStackService 등)를 직접 참조하지 않고 이 함수를 거쳐야 합니다.
+func SyncVMsForParticipant(participant *models.Participant) ([]models.VirtualMachine, error) {
+	provider, err := GetCloudProvider(participant.ProviderType)
+	if err != nil {
+		return nil, err
+	}
+
+	instances, err := provider.ListVMs(participant)
+	if err != nil {
+		return nil, fmt.Errorf("VM 목록 조회 실패: %v", err)
+	}
+
+	syncedVMs := make([]models.VirtualMachine, 0, len(instances))
+	for _, instance := range instances {
+		// IP 주소 직렬화
+		ipAddressesJSON, _ := json.Marshal(instance.Addresses)
+
+		// VM 정보 구성 (DB에 저장할 안정적인 정보만)
+		syncedVMs = append(syncedVMs, models.VirtualMachine{
+			InstanceID:       instance.ID,
+			Name:             instance.Name,
+			ParticipantID:    participant.ID,
+			Status:           instance.Status,
+			FlavorID:         instance.Flavor.ID,
+			FlavorName:       instance.Flavor.Name,
+			VCPUs:            instance.Flavor.VCPUs,
+			RAM:              instance.Flavor.RAM,
+			Disk:             instance.Flavor.Disk,
+			IPAddresses:      string(ipAddressesJSON),
+			AvailabilityZone: instance.AvailabilityZone,
+		})
+	}
+
+	return syncedVMs, nil
+}
+
+// AssignFederatedLearningTaskForParticipant는 participant.ProviderType에 등록된
+// CloudProvider를 통해 연합학습 작업을 할당합니다.
+func AssignFederatedLearningTaskForParticipant(participant *models.Participant, vm *models.VirtualMachine, taskID string) error {
+	provider, err := GetCloudProvider(participant.ProviderType)
+	if err != nil {
+		return err
+	}
+
+	return provider.AssignTask(participant, vm.InstanceID, taskID)
+}
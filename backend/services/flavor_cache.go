@@ -0,0 +1,137 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Mungge/Fleecy-Cloud/models"
+)
+
+// defaultFlavorCacheTTL은 flavorCache에 저장된 flavor 상세 정보의 기본 유효 기간입니다.
+// flavor는 운영 중 거의 변경되지 않으므로 비교적 길게 잡습니다.
+const defaultFlavorCacheTTL = 10 * time.Minute
+
+// defaultFlavorFetchConcurrency는 flavor 캐시 미스를 병렬로 조회할 때의 기본
+// 최대 동시 요청 수입니다.
+const defaultFlavorFetchConcurrency = 8
+
+// flavorCacheKey는 (엔드포인트, flavorID) 단위로 캐시 항목을 식별합니다. flavorID는
+// OpenStack 배포판마다 다를 수 있어 엔드포인트로 네임스페이스를 나눕니다.
+type flavorCacheKey struct {
+	Endpoint string
+	FlavorID string
+}
+
+type flavorCacheEntry struct {
+	details   FlavorDetails
+	expiresAt time.Time
+}
+
+func (e flavorCacheEntry) expired() bool {
+	return time.Now().After(e.expiresAt)
+}
+
+// flavorCache는 GetFlavorDetails가 조회한 flavor 상세 정보를 TTL 동안 메모리에
+// 캐싱합니다. GetAllVMInstances는 이 캐시를 통해 VM이 많은 목록 조회에서 동일한
+// flavor를 반복 조회하지 않습니다.
+type flavorCache struct {
+	ttl time.Duration
+
+	mu      sync.RWMutex
+	entries map[flavorCacheKey]flavorCacheEntry
+}
+
+func newFlavorCache(ttl time.Duration) *flavorCache {
+	return &flavorCache{
+		ttl:     ttl,
+		entries: make(map[flavorCacheKey]flavorCacheEntry),
+	}
+}
+
+// get은 endpoint/flavorID에 대한 캐싱된 flavor 상세 정보가 있고 아직 유효하면
+// 반환합니다.
+func (c *flavorCache) get(endpoint, flavorID string) (*FlavorDetails, bool) {
+	key := flavorCacheKey{Endpoint: endpoint, FlavorID: flavorID}
+
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if !ok || entry.expired() {
+		return nil, false
+	}
+
+	details := entry.details
+	return &details, true
+}
+
+// set은 endpoint/flavorID에 대한 flavor 상세 정보를 ttl 동안 캐싱합니다.
+func (c *flavorCache) set(endpoint, flavorID string, details FlavorDetails) {
+	key := flavorCacheKey{Endpoint: endpoint, FlavorID: flavorID}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = flavorCacheEntry{details: details, expiresAt: time.Now().Add(c.ttl)}
+}
+
+// PrefetchFlavors는 participant가 속한 OpenStack 배포판의 모든 flavor 상세 정보를
+// 한 번의 요청으로 조회해 flavorCache를 예열합니다. GetAllVMInstances는 이 호출
+// 이후 대부분의 flavor를 캐시에서 찾을 수 있어, 목록 조회마다 flavor 수만큼의
+// API 호출이 발생하는 것을 피할 수 있습니다.
+func (s *OpenStackService) PrefetchFlavors(participant *models.Participant) error {
+	url := fmt.Sprintf("%s/compute/v2.1/flavors/detail", participant.OpenStackEndpoint)
+
+	resp, err := s.doAuthenticated(participant, "Flavor 목록 조회", func(token string) (*http.Request, error) {
+		req, err := newOpenStackRequest(participant, "GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("HTTP 요청 생성 실패: %v", err)
+		}
+		req.Header.Set("X-Auth-Token", token)
+		req.Header.Set("Accept", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Flavor 목록 조회 실패: HTTP %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("응답 읽기 실패: %v", err)
+	}
+
+	var response struct {
+		Flavors []struct {
+			ID    string `json:"id"`
+			Name  string `json:"name"`
+			VCPUs int    `json:"vcpus"`
+			RAM   int    `json:"ram"`
+			Disk  int    `json:"disk"`
+		} `json:"flavors"`
+	}
+
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("응답 파싱 실패: %v", err)
+	}
+
+	for _, flavor := range response.Flavors {
+		s.flavorCache.set(participant.OpenStackEndpoint, flavor.ID, FlavorDetails{
+			ID:    flavor.ID,
+			Name:  flavor.Name,
+			VCPUs: flavor.VCPUs,
+			RAM:   flavor.RAM,
+			Disk:  flavor.Disk,
+		})
+	}
+
+	return nil
+}
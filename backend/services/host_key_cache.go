@@ -0,0 +1,57 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// hostKeyCache는 VM별 SSH 호스트 키를 최초 접속 시 저장해두고(trust-on-first-use),
+// 이후 접속에서 동일한 호스트 키인지 검증합니다. cloud-init 단계에서 호스트 키를
+// 미리 꺼내올 외부 채널이 없는 현재 구조에서, ssh.InsecureIgnoreHostKey()로 검증을
+// 완전히 생략하는 대신 최소한 경로상 공격자가 최초 접속 이후 호스트 키를 바꿔치기하는
+// 것은 탐지할 수 있게 합니다.
+type hostKeyCache struct {
+	mu   sync.RWMutex
+	keys map[string]ssh.PublicKey
+}
+
+func newHostKeyCache() *hostKeyCache {
+	return &hostKeyCache{keys: make(map[string]ssh.PublicKey)}
+}
+
+// callback은 instanceID에 대한 ssh.HostKeyCallback을 반환합니다. 해당 VM에 대해
+// 저장된 호스트 키가 없으면 이번 접속의 호스트 키를 그대로 저장하고 접속을
+// 허용합니다. 이미 저장된 키가 있으면 이번 접속의 호스트 키와 바이트 단위로
+// 비교해, 다르면 접속을 거부합니다.
+func (c *hostKeyCache) callback(instanceID string) ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		c.mu.RLock()
+		pinned, ok := c.keys[instanceID]
+		c.mu.RUnlock()
+
+		if !ok {
+			c.mu.Lock()
+			c.keys[instanceID] = key
+			c.mu.Unlock()
+			return nil
+		}
+
+		if !bytes.Equal(pinned.Marshal(), key.Marshal()) {
+			return fmt.Errorf("VM %s의 SSH 호스트 키가 최초 접속 시와 다릅니다 (MITM 의심)", instanceID)
+		}
+
+		return nil
+	}
+}
+
+// forgetHostKey는 instanceID에 대해 저장된 호스트 키를 제거합니다. VM을 재생성해
+// 호스트 키가 바뀌는 것이 예상되는 경우(재프로비저닝 등) 호출합니다.
+func (c *hostKeyCache) forgetHostKey(instanceID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.keys, instanceID)
+}
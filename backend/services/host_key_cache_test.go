@@ -0,0 +1,95 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"net"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// newTestSSHPublicKey는 테스트용 ed25519 키 쌍에서 ssh.PublicKey를 생성합니다.
+func newTestSSHPublicKey(t *testing.T) ssh.PublicKey {
+	t.Helper()
+
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("테스트 키 생성 실패: %v", err)
+	}
+
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("ssh.PublicKey 변환 실패: %v", err)
+	}
+
+	return sshPub
+}
+
+func TestHostKeyCachePinsOnFirstConnection(t *testing.T) {
+	cache := newHostKeyCache()
+	key := newTestSSHPublicKey(t)
+
+	if err := cache.callback("instance-1")("host", &net.TCPAddr{}, key); err != nil {
+		t.Fatalf("first connection should pin the host key without error, got: %v", err)
+	}
+}
+
+func TestHostKeyCacheAllowsMatchingKeyOnReconnect(t *testing.T) {
+	cache := newHostKeyCache()
+	key := newTestSSHPublicKey(t)
+	callback := cache.callback("instance-1")
+
+	if err := callback("host", &net.TCPAddr{}, key); err != nil {
+		t.Fatalf("first connection failed: %v", err)
+	}
+
+	if err := callback("host", &net.TCPAddr{}, key); err != nil {
+		t.Fatalf("reconnecting with the same host key should succeed, got: %v", err)
+	}
+}
+
+func TestHostKeyCacheRejectsMismatchedKeyOnReconnect(t *testing.T) {
+	cache := newHostKeyCache()
+	first := newTestSSHPublicKey(t)
+	second := newTestSSHPublicKey(t)
+	callback := cache.callback("instance-1")
+
+	if err := callback("host", &net.TCPAddr{}, first); err != nil {
+		t.Fatalf("first connection failed: %v", err)
+	}
+
+	if err := callback("host", &net.TCPAddr{}, second); err == nil {
+		t.Fatal("reconnecting with a different host key should be rejected")
+	}
+}
+
+func TestHostKeyCacheForgetAllowsRePinning(t *testing.T) {
+	cache := newHostKeyCache()
+	first := newTestSSHPublicKey(t)
+	second := newTestSSHPublicKey(t)
+	callback := cache.callback("instance-1")
+
+	if err := callback("host", &net.TCPAddr{}, first); err != nil {
+		t.Fatalf("first connection failed: %v", err)
+	}
+
+	cache.forgetHostKey("instance-1")
+
+	if err := callback("host", &net.TCPAddr{}, second); err != nil {
+		t.Fatalf("after forgetHostKey, a new key should be re-pinned without error, got: %v", err)
+	}
+}
+
+func TestHostKeyCacheTracksInstancesIndependently(t *testing.T) {
+	cache := newHostKeyCache()
+	keyA := newTestSSHPublicKey(t)
+	keyB := newTestSSHPublicKey(t)
+
+	if err := cache.callback("instance-a")("host", &net.TCPAddr{}, keyA); err != nil {
+		t.Fatalf("pinning instance-a failed: %v", err)
+	}
+
+	if err := cache.callback("instance-b")("host", &net.TCPAddr{}, keyB); err != nil {
+		t.Fatalf("a different instanceID should pin independently, got: %v", err)
+	}
+}
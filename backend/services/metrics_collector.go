@@ -0,0 +1,285 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Mungge/Fleecy-Cloud/models"
+)
+
+// MetricsCollector는 VM의 실시간 모니터링 지표를 수집하는 인터페이스입니다.
+// OpenStackService.GetVMMonitoringInfo는 이 인터페이스를 통해 메트릭을 수집하며,
+// 구현체가 없거나 수집에 실패하면 시뮬레이션 값으로 대체합니다.
+type MetricsCollector interface {
+	CollectVMMetrics(participant *models.Participant, instanceID string) (*models.VMMonitoringInfo, error)
+}
+
+// validateInstanceID는 instanceID가 OpenStack 리소스 UUID 형식인지 확인합니다.
+// Gnocchi measures URL 경로나 PromQL 라벨 매처에 instanceID를 그대로 엮어 넣기
+// 전에 호출해, 조작된 값이 쿼리/경로 구조를 깨뜨리는 것을 막습니다.
+func validateInstanceID(instanceID string) error {
+	if !uuidSegmentPattern.MatchString(instanceID) {
+		return fmt.Errorf("instanceID가 올바른 OpenStack UUID 형식이 아닙니다: %s", instanceID)
+	}
+	return nil
+}
+
+// GnocchiMetricsCollector는 OpenStack Gnocchi/Ceilometer telemetry API를 통해
+// 메트릭을 수집합니다. openstack.client(openstackTransport)를 통해 요청을 보내,
+// 다른 OpenStack API 호출과 동일하게 rate limit/재시도/circuit breaker가 적용되고
+// 토큰이 401로 거절되면 자동으로 갱신 후 재시도합니다.
+type GnocchiMetricsCollector struct {
+	openstack *OpenStackService
+	// Granularity는 조회할 측정값의 granularity(초 단위 문자열, 예: "300")입니다.
+	Granularity string
+	// Window은 measures를 조회할 때 현재 시각부터 거슬러 올라갈 시간 범위입니다.
+	Window time.Duration
+}
+
+// NewGnocchiMetricsCollector는 기본 granularity(300초)와 기본 조회 구간(10분)을
+// 사용하는 GnocchiMetricsCollector를 생성합니다.
+func NewGnocchiMetricsCollector(openstack *OpenStackService) *GnocchiMetricsCollector {
+	return &GnocchiMetricsCollector{
+		openstack:   openstack,
+		Granularity: "300",
+		Window:      10 * time.Minute,
+	}
+}
+
+// fetchMeasure는 지정한 Gnocchi metric에 대해 [now-Window, now] 구간의 가장 최근
+// 측정값을 조회합니다.
+func (c *GnocchiMetricsCollector) fetchMeasure(participant *models.Participant, instanceID, metric string) (float64, error) {
+	now := time.Now()
+	endpoint := fmt.Sprintf(
+		"%s/metric/v1/resource/instance/%s/metric/%s/measures?aggregation=mean&granularity=%s&start=%s&stop=%s",
+		participant.OpenStackEndpoint, instanceID, metric, c.Granularity,
+		url.QueryEscape(now.Add(-c.Window).Format(time.RFC3339)),
+		url.QueryEscape(now.Format(time.RFC3339)),
+	)
+
+	resp, err := c.openstack.doAuthenticated(participant, fmt.Sprintf("%s 메트릭 조회", metric), func(token string) (*http.Request, error) {
+		req, err := newOpenStackRequest(participant, "GET", endpoint, nil)
+		if err != nil {
+			return nil, fmt.Errorf("HTTP 요청 생성 실패: %v", err)
+		}
+		req.Header.Set("X-Auth-Token", token)
+		req.Header.Set("Accept", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("응답 읽기 실패: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("%s 메트릭 조회 실패: HTTP %d, 응답: %s", metric, resp.StatusCode, string(body))
+	}
+
+	// Gnocchi measures 응답은 [timestamp, granularity, value] 형식의 배열 목록입니다.
+	var measures [][]interface{}
+	if err := json.Unmarshal(body, &measures); err != nil {
+		return 0, fmt.Errorf("측정값 파싱 실패: %v", err)
+	}
+
+	if len(measures) == 0 {
+		return 0, nil
+	}
+
+	last := measures[len(measures)-1]
+	if len(last) != 3 {
+		return 0, fmt.Errorf("%s 측정값 형식이 올바르지 않습니다", metric)
+	}
+
+	value, ok := last[2].(float64)
+	if !ok {
+		return 0, fmt.Errorf("%s 측정값 타입이 올바르지 않습니다", metric)
+	}
+
+	return value, nil
+}
+
+// CollectVMMetrics는 cpu_util, memory.usage, disk.device.usage,
+// network.incoming/outgoing.bytes.rate 메트릭을 조회하여 모니터링 정보를 구성합니다.
+func (c *GnocchiMetricsCollector) CollectVMMetrics(participant *models.Participant, instanceID string) (*models.VMMonitoringInfo, error) {
+	if err := validateInstanceID(instanceID); err != nil {
+		return nil, err
+	}
+
+	cpuUsage, err := c.fetchMeasure(participant, instanceID, "cpu_util")
+	if err != nil {
+		return nil, err
+	}
+
+	memUsage, err := c.fetchMeasure(participant, instanceID, "memory.usage")
+	if err != nil {
+		return nil, err
+	}
+
+	diskUsage, err := c.fetchMeasure(participant, instanceID, "disk.device.usage")
+	if err != nil {
+		return nil, err
+	}
+
+	netIn, err := c.fetchMeasure(participant, instanceID, "network.incoming.bytes.rate")
+	if err != nil {
+		return nil, err
+	}
+
+	netOut, err := c.fetchMeasure(participant, instanceID, "network.outgoing.bytes.rate")
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.VMMonitoringInfo{
+		InstanceID:      instanceID,
+		CPUUsage:        cpuUsage,
+		MemoryUsage:     memUsage,
+		DiskUsage:       diskUsage,
+		NetworkInBytes:  int64(netIn),
+		NetworkOutBytes: int64(netOut),
+		LastUpdated:     time.Now(),
+	}, nil
+}
+
+// PrometheusMetricsCollector는 node-exporter/libvirt-exporter가 노출하는 시계열을
+// Prometheus의 query_range API를 통해 수집합니다.
+type PrometheusMetricsCollector struct {
+	BaseURL string
+	client  *http.Client
+	// Window은 각 쿼리가 집계하는 과거 시간 범위(rate() 등의 range vector 길이 포함)입니다.
+	Window time.Duration
+	// Step은 query_range 응답의 샘플 간격입니다.
+	Step time.Duration
+}
+
+// NewPrometheusMetricsCollector는 주어진 Prometheus 서버 주소와 기본 조회 구간(5분),
+// 기본 step(30초)을 사용하는 PrometheusMetricsCollector를 생성합니다.
+func NewPrometheusMetricsCollector(baseURL string) *PrometheusMetricsCollector {
+	return &PrometheusMetricsCollector{
+		BaseURL: baseURL,
+		client:  &http.Client{Timeout: 30 * time.Second},
+		Window:  5 * time.Minute,
+		Step:    30 * time.Second,
+	}
+}
+
+type prometheusQueryRangeResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Result []struct {
+			Values [][2]interface{} `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// queryRange는 최근 Window 구간에 대한 PromQL 쿼리를 Step 간격으로 실행하고 가장
+// 최근 값을 반환합니다.
+func (c *PrometheusMetricsCollector) queryRange(query string) (float64, error) {
+	now := time.Now()
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("start", strconv.FormatInt(now.Add(-c.Window).Unix(), 10))
+	params.Set("end", strconv.FormatInt(now.Unix(), 10))
+	params.Set("step", fmt.Sprintf("%ds", int(c.Step.Seconds())))
+
+	endpoint := fmt.Sprintf("%s/api/v1/query_range?%s", c.BaseURL, params.Encode())
+
+	resp, err := c.client.Get(endpoint)
+	if err != nil {
+		return 0, fmt.Errorf("Prometheus 쿼리 실패: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("응답 읽기 실패: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("Prometheus 쿼리 실패: HTTP %d, 응답: %s", resp.StatusCode, string(body))
+	}
+
+	var result prometheusQueryRangeResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return 0, fmt.Errorf("응답 파싱 실패: %v", err)
+	}
+
+	if len(result.Data.Result) == 0 || len(result.Data.Result[0].Values) == 0 {
+		return 0, nil
+	}
+
+	values := result.Data.Result[0].Values
+	last := values[len(values)-1]
+
+	valueStr, ok := last[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("응답값 타입이 올바르지 않습니다")
+	}
+
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return 0, fmt.Errorf("응답값 파싱 실패: %v", err)
+	}
+
+	return value, nil
+}
+
+// CollectVMMetrics는 libvirt-exporter 시계열을 기반으로 CPU/메모리/네트워크
+// 사용량을 조회합니다. disk.device.usage에 대응하는 시계열은 제공되지 않으므로
+// DiskUsage는 0으로 채워집니다.
+func (c *PrometheusMetricsCollector) CollectVMMetrics(participant *models.Participant, instanceID string) (*models.VMMonitoringInfo, error) {
+	if err := validateInstanceID(instanceID); err != nil {
+		return nil, err
+	}
+
+	rangeVector := fmt.Sprintf("%ds", int(c.Window.Seconds()))
+
+	cpuUsage, err := c.queryRange(fmt.Sprintf(
+		`avg(rate(libvirt_domain_info_cpu_time_seconds_total{domain="%s"}[%s])) * 100`, instanceID, rangeVector,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	memUsage, err := c.queryRange(fmt.Sprintf(
+		`libvirt_domain_info_memory_usage_bytes{domain="%s"} / libvirt_domain_info_maximum_memory_bytes{domain="%s"} * 100`,
+		instanceID, instanceID,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	netIn, err := c.queryRange(fmt.Sprintf(
+		`rate(libvirt_domain_interface_stats_receive_bytes_total{domain="%s"}[%s])`, instanceID, rangeVector,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	netOut, err := c.queryRange(fmt.Sprintf(
+		`rate(libvirt_domain_interface_stats_transmit_bytes_total{domain="%s"}[%s])`, instanceID, rangeVector,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.VMMonitoringInfo{
+		InstanceID:      instanceID,
+		CPUUsage:        cpuUsage,
+		MemoryUsage:     memUsage,
+		DiskUsage:       0,
+		NetworkInBytes:  int64(netIn),
+		NetworkOutBytes: int64(netOut),
+		LastUpdated:     time.Now(),
+	}, nil
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Mungge/Fleecy-Cloud/models"
+)
+
+// defaultMonitoringPollInterval은 monitoringPoller가 VM 모니터링 지표를
+// 백그라운드에서 갱신하는 기본 주기입니다.
+const defaultMonitoringPollInterval = 30 * time.Second
+
+// defaultMonitoringCacheTTL은 monitoringPoller 캐시에 저장된 모니터링 지표의
+// 기본 유효 기간입니다. pollInterval보다 여유 있게 잡아, 폴링이 한 번 밀리더라도
+// 캐시가 만료되어 GetVMMonitoringInfo가 그 자리에서 동기 수집을 하지 않도록 합니다.
+const defaultMonitoringCacheTTL = 90 * time.Second
+
+// monitoringPollerIdleTimeout은 이 기간 동안 GetVMMonitoringInfo 호출이 없었던
+// (participant, instance) 조합의 백그라운드 폴러를 정리하는 기준입니다. 삭제된
+// VM이나 더 이상 조회되지 않는 VM에 대해 폴러가 무한히 쌓이는 것을 막습니다.
+const monitoringPollerIdleTimeout = 5 * time.Minute
+
+// monitoringEvictionInterval은 유휴 폴러를 정리하는 주기입니다.
+const monitoringEvictionInterval = time.Minute
+
+// monitoringKey는 participant와 VM 인스턴스 단위로 모니터링 캐시/폴러 항목을
+// 식별합니다.
+type monitoringKey struct {
+	ParticipantID uint
+	InstanceID    string
+}
+
+type monitoringCacheEntry struct {
+	info      *models.VMMonitoringInfo
+	expiresAt time.Time
+}
+
+func (e monitoringCacheEntry) expired() bool {
+	return time.Now().After(e.expiresAt)
+}
+
+// monitoringPollerEntry는 단일 (participant, instance) 조합을 주기적으로
+// 갱신하는 백그라운드 고루틴의 제어 정보입니다.
+type monitoringPollerEntry struct {
+	stop       chan struct{}
+	lastAccess time.Time
+}
+
+// monitoringPoller는 GetVMMonitoringInfo 호출을 위해 VM별 모니터링 지표를
+// 백그라운드에서 주기적으로 수집해 TTL 동안 캐싱합니다. 동일 VM을 짧은 간격으로
+// 반복 조회하는 핸들러 호출이 매번 metrics 백엔드를 두드리지 않도록 합니다.
+type monitoringPoller struct {
+	pollInterval time.Duration
+	ttl          time.Duration
+
+	mu    sync.Mutex
+	cache map[monitoringKey]monitoringCacheEntry
+
+	pollersMu sync.Mutex
+	pollers   map[monitoringKey]*monitoringPollerEntry
+}
+
+func newMonitoringPoller(pollInterval, ttl time.Duration) *monitoringPoller {
+	p := &monitoringPoller{
+		pollInterval: pollInterval,
+		ttl:          ttl,
+		cache:        make(map[monitoringKey]monitoringCacheEntry),
+		pollers:      make(map[monitoringKey]*monitoringPollerEntry),
+	}
+
+	go p.evictIdlePollersLoop()
+
+	return p
+}
+
+// get은 캐싱된 모니터링 지표가 있으면 그것을 반환하고, 없으면 collect를 동기
+// 호출해 캐시를 채웁니다. 두 경우 모두 해당 (participantID, instanceID)에 대한
+// 백그라운드 폴러가 떠 있는지 확인하고, 없으면 새로 시작합니다.
+func (p *monitoringPoller) get(participantID uint, instanceID string, collect func() (*models.VMMonitoringInfo, error)) (*models.VMMonitoringInfo, error) {
+	key := monitoringKey{ParticipantID: participantID, InstanceID: instanceID}
+
+	p.touch(key)
+
+	p.mu.Lock()
+	entry, ok := p.cache[key]
+	p.mu.Unlock()
+
+	if ok && !entry.expired() {
+		p.ensurePolling(key, collect)
+		return entry.info, nil
+	}
+
+	info, err := collect()
+	if err != nil {
+		return nil, err
+	}
+
+	p.set(key, info)
+	p.ensurePolling(key, collect)
+
+	return info, nil
+}
+
+func (p *monitoringPoller) set(key monitoringKey, info *models.VMMonitoringInfo) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cache[key] = monitoringCacheEntry{info: info, expiresAt: time.Now().Add(p.ttl)}
+}
+
+func (p *monitoringPoller) touch(key monitoringKey) {
+	p.pollersMu.Lock()
+	defer p.pollersMu.Unlock()
+	if entry, ok := p.pollers[key]; ok {
+		entry.lastAccess = time.Now()
+	}
+}
+
+// ensurePolling은 key에 대한 백그라운드 폴러가 없으면 새로 시작합니다.
+func (p *monitoringPoller) ensurePolling(key monitoringKey, collect func() (*models.VMMonitoringInfo, error)) {
+	p.pollersMu.Lock()
+	defer p.pollersMu.Unlock()
+
+	if _, ok := p.pollers[key]; ok {
+		return
+	}
+
+	entry := &monitoringPollerEntry{stop: make(chan struct{}), lastAccess: time.Now()}
+	p.pollers[key] = entry
+
+	go p.pollLoop(key, entry, collect)
+}
+
+func (p *monitoringPoller) pollLoop(key monitoringKey, entry *monitoringPollerEntry, collect func() (*models.VMMonitoringInfo, error)) {
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if info, err := collect(); err == nil {
+				p.set(key, info)
+			}
+		case <-entry.stop:
+			return
+		}
+	}
+}
+
+// evictIdlePollersLoop는 monitoringPollerIdleTimeout 이상 조회되지 않은
+// (participant, instance) 조합의 폴러와 캐시 항목을 주기적으로 정리합니다.
+func (p *monitoringPoller) evictIdlePollersLoop() {
+	ticker := time.NewTicker(monitoringEvictionInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		now := time.Now()
+
+		p.pollersMu.Lock()
+		for key, entry := range p.pollers {
+			if now.Sub(entry.lastAccess) > monitoringPollerIdleTimeout {
+				close(entry.stop)
+				delete(p.pollers, key)
+
+				p.mu.Lock()
+				delete(p.cache, key)
+				p.mu.Unlock()
+			}
+		}
+		p.pollersMu.Unlock()
+	}
+}
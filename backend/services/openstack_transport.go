@@ -0,0 +1,353 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mungge/Fleecy-Cloud/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+var openstackRequestDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "openstack_request_duration_seconds",
+		Help:    "OpenStack API 요청의 처리 시간(초)",
+		Buckets: prometheus.DefBuckets,
+	},
+	[]string{"op", "endpoint", "status"},
+)
+
+func init() {
+	prometheus.MustRegister(openstackRequestDuration)
+}
+
+// participantContextKey는 요청 컨텍스트에 rate limiting용 참여자 식별자를
+// 실어 보내기 위한 키입니다.
+type participantContextKey struct{}
+
+func contextWithParticipant(ctx context.Context, participant *models.Participant) context.Context {
+	return context.WithValue(ctx, participantContextKey{}, fmt.Sprintf("%v", participant.ID))
+}
+
+func participantFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(participantContextKey{}).(string)
+	return key, ok
+}
+
+// newOpenStackRequest는 http.NewRequest를 감싸 요청 컨텍스트에 participant를
+// 태깅합니다. openstackTransport는 이 값을 참여자별 rate limiter의 키로 사용합니다.
+func newOpenStackRequest(participant *models.Participant, method, url string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return req.WithContext(contextWithParticipant(req.Context(), participant)), nil
+}
+
+// circuitState는 circuitBreaker의 현재 상태입니다.
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+)
+
+// circuitBreaker는 엔드포인트별로 연속 실패 횟수를 추적해, 임계치를 넘으면
+// resetTimeout 동안 요청을 즉시 실패시켜 폴러가 타임아웃으로 멈추지 않게 합니다.
+type circuitBreaker struct {
+	mu               sync.Mutex
+	failureThreshold int
+	resetTimeout     time.Duration
+	consecutiveFails int
+	state            circuitState
+	openedAt         time.Time
+}
+
+func newCircuitBreaker(failureThreshold int, resetTimeout time.Duration) *circuitBreaker {
+	return &circuitBreaker{failureThreshold: failureThreshold, resetTimeout: resetTimeout}
+}
+
+// allow는 요청을 진행해도 되는지 판단합니다. open 상태에서 resetTimeout이
+// 지나면 closed 상태로 되돌리고 연속 실패 횟수를 0으로 초기화해 요청을 다시
+// 허용합니다. 별도의 half-open 단계는 없어 요청 수를 probe 하나로 제한하지 않으며,
+// 다시 열리려면 failureThreshold번 연속 실패해야 합니다.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == circuitOpen {
+		if time.Since(b.openedAt) <= b.resetTimeout {
+			return false
+		}
+		b.state = circuitClosed
+		b.consecutiveFails = 0
+	}
+
+	return true
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutiveFails = 0
+	b.state = circuitClosed
+}
+
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutiveFails++
+	if b.consecutiveFails >= b.failureThreshold {
+		b.state = circuitOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// transportIdleEviction은 limiter/breaker 항목이 이 기간 동안 사용되지 않으면
+// evictIdleLoop이 제거하는 유휴 기준 시간입니다. 인스턴스별 엔드포인트 키
+// (서버 액션 등)는 VM이 삭제된 뒤로는 다시 쓰이지 않으므로, evict하지 않으면
+// 맵이 프로세스 수명 내내 무한정 자랍니다.
+const transportIdleEviction = 30 * time.Minute
+
+// transportEvictionInterval은 evictIdleLoop이 유휴 항목을 훑는 주기입니다.
+const transportEvictionInterval = 5 * time.Minute
+
+// uuidSegmentPattern은 URL 경로 세그먼트 중 OpenStack 리소스 UUID(서버 ID 등)에
+// 해당하는 부분을 찾습니다.
+var uuidSegmentPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// normalizeEndpointPath는 경로의 UUID 세그먼트를 "{id}"로 치환해, 인스턴스별로
+// 다른 경로(/compute/v2.1/servers/{uuid}/action 등)가 모두 같은 circuit
+// breaker/메트릭 라벨로 모이도록 합니다.
+func normalizeEndpointPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if uuidSegmentPattern.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+type breakerEntry struct {
+	breaker  *circuitBreaker
+	lastUsed time.Time
+}
+
+// openstackTransport는 참여자별 토큰 버킷 rate limiting, idempotent GET의
+// 지수 백오프+jitter 재시도(Retry-After 반영), 엔드포인트별 circuit breaker,
+// 구조화 로그 및 Prometheus 히스토그램을 적용하는 http.RoundTripper입니다.
+type openstackTransport struct {
+	next http.RoundTripper
+
+	rateLimit  rate.Limit
+	rateBurst  int
+	maxRetries int
+
+	failureThreshold int
+	resetTimeout     time.Duration
+
+	limitersMu sync.Mutex
+	limiters   map[string]*limiterEntry
+
+	breakersMu sync.Mutex
+	breakers   map[string]*breakerEntry
+}
+
+func newOpenstackTransport(next http.RoundTripper, rateLimit rate.Limit, rateBurst, maxRetries, failureThreshold int, resetTimeout time.Duration) *openstackTransport {
+	t := &openstackTransport{
+		next:             next,
+		rateLimit:        rateLimit,
+		rateBurst:        rateBurst,
+		maxRetries:       maxRetries,
+		failureThreshold: failureThreshold,
+		resetTimeout:     resetTimeout,
+		limiters:         make(map[string]*limiterEntry),
+		breakers:         make(map[string]*breakerEntry),
+	}
+
+	go t.evictIdleLoop()
+
+	return t
+}
+
+// evictIdleLoop는 transportEvictionInterval마다 transportIdleEviction 동안
+// 쓰이지 않은 limiter/breaker 항목을 제거해, 더 이상 존재하지 않는 VM의
+// per-instance 엔드포인트 키가 맵에 영구히 쌓이는 것을 막습니다.
+func (t *openstackTransport) evictIdleLoop() {
+	ticker := time.NewTicker(transportEvictionInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		now := time.Now()
+
+		t.limitersMu.Lock()
+		for key, entry := range t.limiters {
+			if now.Sub(entry.lastUsed) > transportIdleEviction {
+				delete(t.limiters, key)
+			}
+		}
+		t.limitersMu.Unlock()
+
+		t.breakersMu.Lock()
+		for key, entry := range t.breakers {
+			if now.Sub(entry.lastUsed) > transportIdleEviction {
+				delete(t.breakers, key)
+			}
+		}
+		t.breakersMu.Unlock()
+	}
+}
+
+func (t *openstackTransport) limiterFor(key string) *rate.Limiter {
+	t.limitersMu.Lock()
+	defer t.limitersMu.Unlock()
+
+	entry, ok := t.limiters[key]
+	if !ok {
+		entry = &limiterEntry{limiter: rate.NewLimiter(t.rateLimit, t.rateBurst)}
+		t.limiters[key] = entry
+	}
+	entry.lastUsed = time.Now()
+
+	return entry.limiter
+}
+
+func (t *openstackTransport) breakerFor(endpoint string) *circuitBreaker {
+	t.breakersMu.Lock()
+	defer t.breakersMu.Unlock()
+
+	entry, ok := t.breakers[endpoint]
+	if !ok {
+		entry = &breakerEntry{breaker: newCircuitBreaker(t.failureThreshold, t.resetTimeout)}
+		t.breakers[endpoint] = entry
+	}
+	entry.lastUsed = time.Now()
+
+	return entry.breaker
+}
+
+// endpointKey는 circuit breaker와 메트릭 라벨에 사용할 엔드포인트 식별자입니다.
+// 호스트를 포함시켜 서로 다른 참여자의 OpenStack 배포판이 같은 상대 경로를
+// 공유하더라도 키가 섞이지 않게 하고, 경로의 UUID 세그먼트는 정규화해 VM마다
+// 새 키가 생기지 않게 합니다.
+func endpointKey(req *http.Request) string {
+	return req.Method + " " + req.URL.Host + normalizeEndpointPath(req.URL.Path)
+}
+
+// backoffWithJitter는 시도 횟수에 따라 지수적으로 증가하는 대기 시간에 지터를
+// 더합니다.
+func backoffWithJitter(attempt int) time.Duration {
+	base := time.Duration(1<<uint(attempt)) * 500 * time.Millisecond
+	if base > 30*time.Second {
+		base = 30 * time.Second
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(base) + 1))
+	return base/2 + jitter/2
+}
+
+// retryAfter는 응답의 Retry-After 헤더를 우선 반영하고, 없으면 지수 백오프를
+// 사용합니다.
+func retryAfter(resp *http.Response, attempt int) time.Duration {
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+		if when, err := http.ParseTime(value); err == nil {
+			return time.Until(when)
+		}
+	}
+
+	return backoffWithJitter(attempt)
+}
+
+// RoundTrip은 요청을 참여자별 rate limiter에 통과시키고, 엔드포인트의 circuit
+// breaker가 열려 있으면 즉시 실패시킵니다. idempotent한 GET 요청은 429/5xx
+// 응답이나 전송 에러에 대해 재시도합니다.
+func (t *openstackTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	participantKey, ok := participantFromContext(req.Context())
+	if !ok {
+		participantKey = req.URL.Host
+	}
+
+	endpoint := endpointKey(req)
+	breaker := t.breakerFor(endpoint)
+
+	if !breaker.allow() {
+		return nil, fmt.Errorf("circuit breaker open: %s", endpoint)
+	}
+
+	limiter := t.limiterFor(participantKey)
+	retryable := req.Method == http.MethodGet
+
+	maxAttempts := 1
+	if retryable {
+		maxAttempts = t.maxRetries + 1
+	}
+
+	var lastErr error
+	var resp *http.Response
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if err := limiter.Wait(req.Context()); err != nil {
+			return nil, fmt.Errorf("rate limiter 대기 실패: %v", err)
+		}
+
+		start := time.Now()
+		resp, lastErr = t.next.RoundTrip(req)
+		duration := time.Since(start)
+
+		status := "error"
+		if lastErr == nil {
+			status = strconv.Itoa(resp.StatusCode)
+		}
+		openstackRequestDuration.WithLabelValues(req.Method, endpoint, status).Observe(duration.Seconds())
+		log.Printf("openstack request op=%s endpoint=%s attempt=%d status=%s duration=%s", req.Method, endpoint, attempt+1, status, duration)
+
+		if lastErr != nil {
+			breaker.recordFailure()
+			if !retryable || attempt == maxAttempts-1 {
+				return nil, lastErr
+			}
+			time.Sleep(backoffWithJitter(attempt))
+			continue
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			breaker.recordFailure()
+			if !retryable || attempt == maxAttempts-1 {
+				return resp, nil
+			}
+
+			wait := retryAfter(resp, attempt)
+			resp.Body.Close()
+			time.Sleep(wait)
+			continue
+		}
+
+		breaker.recordSuccess()
+		return resp, nil
+	}
+
+	return resp, lastErr
+}
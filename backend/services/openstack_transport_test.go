@@ -0,0 +1,115 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerOpensAfterThreshold(t *testing.T) {
+	b := newCircuitBreaker(3, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if !b.allow() {
+			t.Fatalf("breaker should still allow requests before reaching the threshold (failure #%d)", i+1)
+		}
+		b.recordFailure()
+	}
+
+	if !b.allow() {
+		t.Fatal("breaker should still be closed with only 2 consecutive failures against a threshold of 3")
+	}
+	b.recordFailure()
+
+	if b.allow() {
+		t.Fatal("breaker should be open once consecutive failures reach the threshold")
+	}
+}
+
+func TestCircuitBreakerRecordSuccessResetsFailures(t *testing.T) {
+	b := newCircuitBreaker(2, time.Minute)
+
+	b.recordFailure()
+	b.recordSuccess()
+	b.recordFailure()
+
+	if !b.allow() {
+		t.Fatal("a success should reset the consecutive failure count, so a single subsequent failure must not open the breaker")
+	}
+}
+
+func TestCircuitBreakerAllowsRequestsAfterResetTimeout(t *testing.T) {
+	b := newCircuitBreaker(1, 10*time.Millisecond)
+
+	b.recordFailure()
+	if b.allow() {
+		t.Fatal("breaker should be open immediately after crossing the failure threshold")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if !b.allow() {
+		t.Fatal("breaker should allow requests again once resetTimeout has elapsed")
+	}
+}
+
+func TestBackoffWithJitterCapsAtMax(t *testing.T) {
+	d := backoffWithJitter(10)
+	if d > 30*time.Second {
+		t.Fatalf("backoff should be capped at 30s, got %s", d)
+	}
+	if d < 0 {
+		t.Fatalf("backoff must not be negative, got %s", d)
+	}
+}
+
+func TestBackoffWithJitterGrowsWithAttempt(t *testing.T) {
+	// jitter 때문에 단일 값을 비교할 수 없으므로, 여러 샘플의 최댓값이 시도
+	// 횟수에 따라 커지는지로 증가 추세를 확인합니다.
+	maxAt := func(attempt int) time.Duration {
+		var max time.Duration
+		for i := 0; i < 50; i++ {
+			if d := backoffWithJitter(attempt); d > max {
+				max = d
+			}
+		}
+		return max
+	}
+
+	if maxAt(0) >= maxAt(4) {
+		t.Fatalf("backoff should trend upward with attempt count: attempt0 max=%s, attempt4 max=%s", maxAt(0), maxAt(4))
+	}
+}
+
+func TestRetryAfterUsesHeaderSeconds(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+
+	d := retryAfter(resp, 0)
+	if d != 2*time.Second {
+		t.Fatalf("expected 2s from Retry-After header, got %s", d)
+	}
+}
+
+func TestRetryAfterFallsBackToBackoff(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+
+	d := retryAfter(resp, 0)
+	if d <= 0 || d > 30*time.Second {
+		t.Fatalf("expected a backoffWithJitter value in (0, 30s], got %s", d)
+	}
+}
+
+func TestNormalizeEndpointPathCollapsesUUIDSegments(t *testing.T) {
+	cases := map[string]string{
+		"/compute/v2.1/servers/detail":                                      "/compute/v2.1/servers/detail",
+		"/compute/v2.1/servers/1b4e28ba-2fa1-11d2-883f-0016d3cca427/action": "/compute/v2.1/servers/{id}/action",
+		"/compute/v2.1/flavors/1b4e28ba-2fa1-11d2-883f-0016d3cca427":        "/compute/v2.1/flavors/{id}",
+		"/compute/v2.1/servers/not-a-uuid/action":                           "/compute/v2.1/servers/not-a-uuid/action",
+	}
+
+	for in, want := range cases {
+		if got := normalizeEndpointPath(in); got != want {
+			t.Errorf("normalizeEndpointPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Mungge/Fleecy-Cloud/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenExpiryMargin만큼 만료 시각 이전부터는 캐시된 토큰을 만료된 것으로 간주해,
+// 요청 중간에 토큰이 만료되는 경계 상황을 피합니다.
+const tokenExpiryMargin = 60 * time.Second
+
+// tokenCacheKey는 (participant.ID, credentialID) 쌍으로 캐시 항목을 식별합니다.
+type tokenCacheKey struct {
+	ParticipantID uint
+	CredentialID  string
+}
+
+func (k tokenCacheKey) String() string {
+	return fmt.Sprintf("openstack:token:%d:%s", k.ParticipantID, k.CredentialID)
+}
+
+// tokenCacheKeyFor는 participant로부터 캐시 키를 구성합니다.
+func tokenCacheKeyFor(participant *models.Participant) tokenCacheKey {
+	return tokenCacheKey{
+		ParticipantID: participant.ID,
+		CredentialID:  participant.OpenStackApplicationCredentialID,
+	}
+}
+
+// tokenCacheEntry는 캐싱된 Keystone 토큰과 만료 시각입니다.
+type tokenCacheEntry struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (e tokenCacheEntry) expired() bool {
+	return time.Now().After(e.ExpiresAt.Add(-tokenExpiryMargin))
+}
+
+// tokenCacheBackend는 tokenCache가 값을 실제로 저장하는 백엔드입니다. 기본은
+// 프로세스 메모리이며, Redis 백엔드로 교체하면 토큰이 프로세스 재시작과 여러
+// 레플리카 사이에서 공유됩니다.
+type tokenCacheBackend interface {
+	Get(ctx context.Context, key tokenCacheKey) (*tokenCacheEntry, error)
+	Set(ctx context.Context, key tokenCacheKey, entry tokenCacheEntry) error
+	Delete(ctx context.Context, key tokenCacheKey) error
+}
+
+// memoryTokenCacheBackend는 프로세스 메모리에 토큰을 저장하는 기본 백엔드입니다.
+type memoryTokenCacheBackend struct {
+	mu      sync.RWMutex
+	entries map[tokenCacheKey]tokenCacheEntry
+}
+
+func newMemoryTokenCacheBackend() *memoryTokenCacheBackend {
+	return &memoryTokenCacheBackend{entries: make(map[tokenCacheKey]tokenCacheEntry)}
+}
+
+func (b *memoryTokenCacheBackend) Get(ctx context.Context, key tokenCacheKey) (*tokenCacheEntry, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	entry, ok := b.entries[key]
+	if !ok {
+		return nil, nil
+	}
+
+	return &entry, nil
+}
+
+func (b *memoryTokenCacheBackend) Set(ctx context.Context, key tokenCacheKey, entry tokenCacheEntry) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.entries[key] = entry
+	return nil
+}
+
+func (b *memoryTokenCacheBackend) Delete(ctx context.Context, key tokenCacheKey) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.entries, key)
+	return nil
+}
+
+// redisTokenCacheBackend는 Redis에 토큰을 저장해 프로세스 재시작과 여러 레플리카
+// 사이에 캐시를 공유합니다.
+type redisTokenCacheBackend struct {
+	client *redis.Client
+}
+
+func newRedisTokenCacheBackend(client *redis.Client) *redisTokenCacheBackend {
+	return &redisTokenCacheBackend{client: client}
+}
+
+func (b *redisTokenCacheBackend) Get(ctx context.Context, key tokenCacheKey) (*tokenCacheEntry, error) {
+	raw, err := b.client.Get(ctx, key.String()).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis 토큰 조회 실패: %v", err)
+	}
+
+	var entry tokenCacheEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return nil, fmt.Errorf("redis 토큰 파싱 실패: %v", err)
+	}
+
+	return &entry, nil
+}
+
+func (b *redisTokenCacheBackend) Set(ctx context.Context, key tokenCacheKey, entry tokenCacheEntry) error {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("redis 토큰 직렬화 실패: %v", err)
+	}
+
+	ttl := time.Until(entry.ExpiresAt)
+	if ttl <= 0 {
+		ttl = tokenExpiryMargin
+	}
+
+	if err := b.client.Set(ctx, key.String(), raw, ttl).Err(); err != nil {
+		return fmt.Errorf("redis 토큰 저장 실패: %v", err)
+	}
+
+	return nil
+}
+
+func (b *redisTokenCacheBackend) Delete(ctx context.Context, key tokenCacheKey) error {
+	if err := b.client.Del(ctx, key.String()).Err(); err != nil {
+		return fmt.Errorf("redis 토큰 삭제 실패: %v", err)
+	}
+
+	return nil
+}
+
+// tokenCacheMetrics는 캐시 적중/실패 횟수를 집계합니다.
+type tokenCacheMetrics struct {
+	hits   int64
+	misses int64
+}
+
+func (m *tokenCacheMetrics) Hits() int64   { return atomic.LoadInt64(&m.hits) }
+func (m *tokenCacheMetrics) Misses() int64 { return atomic.LoadInt64(&m.misses) }
+
+// tokenCache는 Keystone 토큰을 (participant.ID, credentialID) 단위로 캐싱합니다.
+// 기본 백엔드는 프로세스 메모리이며 UseRedis로 Redis 백엔드로 교체할 수 있습니다.
+type tokenCache struct {
+	backend tokenCacheBackend
+	metrics tokenCacheMetrics
+}
+
+func newTokenCache() *tokenCache {
+	return &tokenCache{backend: newMemoryTokenCacheBackend()}
+}
+
+// UseRedis는 캐시 백엔드를 Redis로 교체합니다.
+func (c *tokenCache) UseRedis(client *redis.Client) {
+	c.backend = newRedisTokenCacheBackend(client)
+}
+
+// get은 key에 해당하는 유효한 토큰이 캐시에 있으면 반환하고, 없거나 만료에
+// 가까우면 false를 반환합니다.
+func (c *tokenCache) get(key tokenCacheKey) (string, bool) {
+	entry, err := c.backend.Get(context.Background(), key)
+	if err != nil || entry == nil || entry.expired() {
+		atomic.AddInt64(&c.metrics.misses, 1)
+		return "", false
+	}
+
+	atomic.AddInt64(&c.metrics.hits, 1)
+	return entry.Token, true
+}
+
+func (c *tokenCache) set(key tokenCacheKey, token string, expiresAt time.Time) {
+	_ = c.backend.Set(context.Background(), key, tokenCacheEntry{Token: token, ExpiresAt: expiresAt})
+}
+
+func (c *tokenCache) invalidate(key tokenCacheKey) {
+	_ = c.backend.Delete(context.Background(), key)
+}
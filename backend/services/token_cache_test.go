@@ -0,0 +1,96 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenCacheEntryExpired(t *testing.T) {
+	cases := []struct {
+		name      string
+		expiresAt time.Time
+		expired   bool
+	}{
+		{"well in the future", time.Now().Add(time.Hour), false},
+		{"already past", time.Now().Add(-time.Minute), true},
+		{"inside the expiry margin", time.Now().Add(tokenExpiryMargin / 2), true},
+		{"just beyond the expiry margin", time.Now().Add(tokenExpiryMargin * 2), false},
+	}
+
+	for _, tc := range cases {
+		entry := tokenCacheEntry{Token: "t", ExpiresAt: tc.expiresAt}
+		if got := entry.expired(); got != tc.expired {
+			t.Errorf("%s: expired() = %v, want %v", tc.name, got, tc.expired)
+		}
+	}
+}
+
+func TestTokenCacheGetMissOnEmptyCache(t *testing.T) {
+	c := newTokenCache()
+	key := tokenCacheKey{ParticipantID: 1, CredentialID: "cred"}
+
+	if _, ok := c.get(key); ok {
+		t.Fatal("get on an empty cache should miss")
+	}
+	if c.metrics.Misses() != 1 {
+		t.Fatalf("expected 1 recorded miss, got %d", c.metrics.Misses())
+	}
+}
+
+func TestTokenCacheSetThenGetHits(t *testing.T) {
+	c := newTokenCache()
+	key := tokenCacheKey{ParticipantID: 1, CredentialID: "cred"}
+
+	c.set(key, "token-value", time.Now().Add(time.Hour))
+
+	token, ok := c.get(key)
+	if !ok {
+		t.Fatal("get should hit after set with a future expiry")
+	}
+	if token != "token-value" {
+		t.Fatalf("got token %q, want %q", token, "token-value")
+	}
+	if c.metrics.Hits() != 1 {
+		t.Fatalf("expected 1 recorded hit, got %d", c.metrics.Hits())
+	}
+}
+
+func TestTokenCacheGetMissesWhenExpired(t *testing.T) {
+	c := newTokenCache()
+	key := tokenCacheKey{ParticipantID: 1, CredentialID: "cred"}
+
+	c.set(key, "token-value", time.Now().Add(-time.Minute))
+
+	if _, ok := c.get(key); ok {
+		t.Fatal("get should miss once the entry is past its expiry")
+	}
+}
+
+func TestTokenCacheInvalidateRemovesEntry(t *testing.T) {
+	c := newTokenCache()
+	key := tokenCacheKey{ParticipantID: 1, CredentialID: "cred"}
+
+	c.set(key, "token-value", time.Now().Add(time.Hour))
+	c.invalidate(key)
+
+	if _, ok := c.get(key); ok {
+		t.Fatal("get should miss after invalidate")
+	}
+}
+
+func TestTokenCacheKeysAreIndependent(t *testing.T) {
+	c := newTokenCache()
+	keyA := tokenCacheKey{ParticipantID: 1, CredentialID: "cred-a"}
+	keyB := tokenCacheKey{ParticipantID: 2, CredentialID: "cred-b"}
+
+	c.set(keyA, "token-a", time.Now().Add(time.Hour))
+
+	if _, ok := c.get(keyB); ok {
+		t.Fatal("a different key must not see another key's cached token")
+	}
+
+	tokenA, ok := c.get(keyA)
+	if !ok || tokenA != "token-a" {
+		t.Fatalf("expected keyA to still resolve to token-a, got %q, ok=%v", tokenA, ok)
+	}
+}
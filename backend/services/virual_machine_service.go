@@ -2,13 +2,18 @@ package services
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/Mungge/Fleecy-Cloud/models"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
 )
 
 // OpenStack 인증 토큰 응답
@@ -89,18 +94,230 @@ type VMHealthCheckResult struct {
 
 type OpenStackService struct {
 	client *http.Client
+	// metricsCollector는 participantMetricsCollectors에 participant별로 지정된
+	// collector가 없을 때 GetVMMonitoringInfo가 사용하는 기본 MetricsCollector입니다.
+	// 둘 다 비어 있으면 시뮬레이션 값으로 대체합니다.
+	metricsCollector MetricsCollector
+	// participantMetricsCollectorsMu는 participantMetricsCollectors를 보호합니다.
+	participantMetricsCollectorsMu sync.RWMutex
+	// participantMetricsCollectors는 participant.ID별로 지정된 MetricsCollector입니다.
+	// 예를 들어 일부 참여자만 Prometheus 백엔드를 쓰고 나머지는 기본 Gnocchi
+	// collector를 쓰는 경우에 사용합니다.
+	participantMetricsCollectors map[uint]MetricsCollector
+	// monitoringPoller는 VM별 모니터링 지표를 백그라운드에서 주기적으로 수집해
+	// 캐싱합니다. GetVMMonitoringInfo는 호출마다 metrics 백엔드를 두드리는 대신
+	// 이 캐시를 읽습니다.
+	monitoringPoller *monitoringPoller
+	// tokenCache는 Keystone 토큰을 만료 시각까지 캐싱해 매 호출마다 발생하는
+	// 인증 라운드트립을 없앱니다.
+	tokenCache *tokenCache
+	// transport는 client.Transport로 꽂혀 있는 openstackTransport이며,
+	// Option으로 rate limit/재시도/circuit breaker 설정을 조정할 수 있습니다.
+	transport *openstackTransport
+	// flavorCache는 GetAllVMInstances가 조회한 flavor 상세 정보를 엔드포인트별로
+	// 캐싱해, VM이 많은 목록 조회에서 동일한 flavor를 반복해서 조회하지 않게 합니다.
+	flavorCache *flavorCache
+	// flavorFetchConcurrency는 GetAllVMInstances가 flavor 캐시 미스를 병렬로
+	// 조회할 때의 최대 동시 요청 수입니다.
+	flavorFetchConcurrency int
+	// hostKeys는 연합학습 에이전트에 SSH로 접속할 때 VM별 호스트 키를 pinning하는 데
+	// 사용합니다.
+	hostKeys *hostKeyCache
 }
 
-func NewOpenStackService() *OpenStackService {
-	return &OpenStackService{
+// NewOpenStackService는 기본적으로 초당 5건, 버스트 10건의 참여자별 rate limit과
+// idempotent GET 최대 3회 재시도, 5회 연속 실패 시 30초간 열리는 circuit breaker를
+// 적용합니다. flavor 정보는 기본 10분간 캐싱하며 최대 8개까지 동시에 조회하고,
+// VM 모니터링 지표는 기본 30초 주기의 백그라운드 폴러로 갱신합니다. Option으로
+// 이 기본값을 조정할 수 있습니다.
+func NewOpenStackService(opts ...Option) *OpenStackService {
+	transport := newOpenstackTransport(http.DefaultTransport, rate.Limit(5), 10, 3, 5, 30*time.Second)
+
+	s := &OpenStackService{
 		client: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: transport,
 		},
+		tokenCache:                   newTokenCache(),
+		transport:                    transport,
+		flavorCache:                  newFlavorCache(defaultFlavorCacheTTL),
+		flavorFetchConcurrency:       defaultFlavorFetchConcurrency,
+		participantMetricsCollectors: make(map[uint]MetricsCollector),
+		monitoringPoller:             newMonitoringPoller(defaultMonitoringPollInterval, defaultMonitoringCacheTTL),
+		hostKeys:                     newHostKeyCache(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Option은 NewOpenStackService가 생성하는 OpenStackService의 동작을 구성합니다.
+type Option func(*OpenStackService)
+
+// WithRateLimit은 참여자별 초당 허용 요청 수와 버스트 한도를 설정합니다.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(s *OpenStackService) {
+		s.transport.rateLimit = rate.Limit(requestsPerSecond)
+		s.transport.rateBurst = burst
+	}
+}
+
+// WithMaxRetries는 idempotent GET 요청의 최대 재시도 횟수를 설정합니다.
+func WithMaxRetries(maxRetries int) Option {
+	return func(s *OpenStackService) {
+		s.transport.maxRetries = maxRetries
 	}
 }
 
-// OpenStack 인증 토큰 획득 -> TestConnection
+// WithCircuitBreaker는 엔드포인트별 circuit breaker의 연속 실패 임계치와
+// open 상태 유지 시간을 설정합니다.
+func WithCircuitBreaker(failureThreshold int, resetTimeout time.Duration) Option {
+	return func(s *OpenStackService) {
+		s.transport.failureThreshold = failureThreshold
+		s.transport.resetTimeout = resetTimeout
+	}
+}
+
+// WithFlavorCacheTTL은 flavor 상세 정보 캐시의 유효 기간을 설정합니다.
+func WithFlavorCacheTTL(ttl time.Duration) Option {
+	return func(s *OpenStackService) {
+		s.flavorCache = newFlavorCache(ttl)
+	}
+}
+
+// WithFlavorFetchConcurrency는 GetAllVMInstances가 flavor 캐시 미스를 병렬로
+// 조회할 때의 최대 동시 요청 수를 설정합니다.
+func WithFlavorFetchConcurrency(concurrency int) Option {
+	return func(s *OpenStackService) {
+		s.flavorFetchConcurrency = concurrency
+	}
+}
+
+// WithMonitoringPoll은 GetVMMonitoringInfo가 사용하는 백그라운드 폴러의 폴링
+// 주기와 캐시 유효 기간을 설정합니다.
+func WithMonitoringPoll(interval, ttl time.Duration) Option {
+	return func(s *OpenStackService) {
+		s.monitoringPoller = newMonitoringPoller(interval, ttl)
+	}
+}
+
+// SetMetricsCollector는 VM 모니터링에 사용할 기본 MetricsCollector 구현체를
+// 지정합니다. SetMetricsCollectorForParticipant로 특정 participant에 별도의
+// collector가 지정되어 있으면 그 쪽이 우선합니다.
+func (s *OpenStackService) SetMetricsCollector(collector MetricsCollector) {
+	s.metricsCollector = collector
+}
+
+// SetMetricsCollectorForParticipant는 특정 participant에 대해서만 사용할
+// MetricsCollector를 지정합니다. 예를 들어 일부 참여자는 Prometheus 백엔드를,
+// 나머지는 기본 Gnocchi collector를 쓰는 경우에 사용합니다.
+func (s *OpenStackService) SetMetricsCollectorForParticipant(participantID uint, collector MetricsCollector) {
+	s.participantMetricsCollectorsMu.Lock()
+	defer s.participantMetricsCollectorsMu.Unlock()
+	s.participantMetricsCollectors[participantID] = collector
+}
+
+// collectorFor는 participant에게 지정된 MetricsCollector가 있으면 그것을, 없으면
+// 기본 metricsCollector를 반환합니다. 둘 다 없으면 nil을 반환합니다.
+func (s *OpenStackService) collectorFor(participant *models.Participant) MetricsCollector {
+	s.participantMetricsCollectorsMu.RLock()
+	collector, ok := s.participantMetricsCollectors[participant.ID]
+	s.participantMetricsCollectorsMu.RUnlock()
+
+	if ok {
+		return collector
+	}
+
+	return s.metricsCollector
+}
+
+// TokenCacheMetrics는 토큰 캐시의 적중/실패 횟수를 반환합니다.
+func (s *OpenStackService) TokenCacheMetrics() (hits int64, misses int64) {
+	return s.tokenCache.metrics.Hits(), s.tokenCache.metrics.Misses()
+}
+
+// InvalidateToken은 participant에 대해 캐싱된 Keystone 토큰을 제거합니다.
+// 호출 결과 401을 받은 경우 등 토큰이 더 이상 유효하지 않을 때 사용합니다.
+func (s *OpenStackService) InvalidateToken(participant *models.Participant) {
+	s.tokenCache.invalidate(tokenCacheKeyFor(participant))
+}
+
+// SetTokenCacheRedis는 토큰 캐시 백엔드를 Redis로 교체해, 토큰이 프로세스 재시작과
+// 여러 레플리카 사이에서 공유되도록 합니다.
+func (s *OpenStackService) SetTokenCacheRedis(client *redis.Client) {
+	s.tokenCache.UseRedis(client)
+}
+
+// GetAuthToken은 participant의 Keystone 토큰을 반환합니다. 캐시에 ~60초 이상
+// 유효기간이 남은 토큰이 있으면 그대로 재사용하고, 없거나 만료에 가까우면 새로
+// 인증을 수행해 캐시를 채웁니다.
 func (s *OpenStackService) GetAuthToken(participant *models.Participant) (string, error) {
+	key := tokenCacheKeyFor(participant)
+
+	if token, ok := s.tokenCache.get(key); ok {
+		return token, nil
+	}
+
+	token, expiresAt, err := s.authenticate(participant)
+	if err != nil {
+		return "", err
+	}
+
+	s.tokenCache.set(key, token, expiresAt)
+
+	return token, nil
+}
+
+// doAuthenticated는 GetAuthToken의 캐시된 토큰으로 build가 만든 요청을 보냅니다.
+// 응답이 401이면 Keystone이 토큰을 조기 폐기한 것으로 보고 캐시를 비운 뒤 새
+// 토큰으로 한 번만 재시도합니다. build는 시도마다 새로 호출되므로, 재시도 시
+// 요청에 새로 발급받은 토큰을 실어 보낼 수 있습니다. 토큰 캐시를 거치는 모든
+// OpenStack API 호출은 이 헬퍼를 통해야 401을 동일하게 처리합니다.
+func (s *OpenStackService) doAuthenticated(participant *models.Participant, opName string, build func(token string) (*http.Request, error)) (*http.Response, error) {
+	token, err := s.GetAuthToken(participant)
+	if err != nil {
+		return nil, fmt.Errorf("인증 실패: %v", err)
+	}
+
+	req, err := build(token)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s 실패: %v", opName, err)
+	}
+
+	// 캐시된 토큰이 서버 쪽에서 이미 만료/폐기된 경우, 캐시를 비우고 새 토큰으로 한 번 더 시도합니다.
+	if resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+		s.InvalidateToken(participant)
+
+		token, err = s.GetAuthToken(participant)
+		if err != nil {
+			return nil, fmt.Errorf("인증 실패: %v", err)
+		}
+
+		req, err = build(token)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err = s.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("%s 실패: %v", opName, err)
+		}
+	}
+
+	return resp, nil
+}
+
+// authenticate는 Keystone과 직접 통신해 새 토큰을 발급받습니다. -> TestConnection
+func (s *OpenStackService) authenticate(participant *models.Participant) (string, time.Time, error) {
 	authReq := AuthRequest{}
 	
 	// Application Credential 방식만 지원
@@ -115,69 +332,79 @@ func (s *OpenStackService) GetAuthToken(participant *models.Participant) (string
 			Secret: participant.OpenStackApplicationCredentialSecret,
 		}
 	} else {
-		return "", fmt.Errorf("application Credential 인증 정보가 필요합니다")
+		return "", time.Time{}, fmt.Errorf("application Credential 인증 정보가 필요합니다")
 	}
 
 	jsonData, err := json.Marshal(authReq)
 	if err != nil {
-		return "", fmt.Errorf("인증 요청 생성 실패: %v", err)
+		return "", time.Time{}, fmt.Errorf("인증 요청 생성 실패: %v", err)
 	}
 
 	url := fmt.Sprintf("%s/identity/v3/auth/tokens", participant.OpenStackEndpoint)
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	req, err := newOpenStackRequest(participant, "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", fmt.Errorf("HTTP 요청 생성 실패: %v", err)
+		return "", time.Time{}, fmt.Errorf("HTTP 요청 생성 실패: %v", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := s.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("인증 요청 실패: %v", err)
+		return "", time.Time{}, fmt.Errorf("인증 요청 실패: %v", err)
 	}
 	defer resp.Body.Close()
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("응답 읽기 실패: %v", err)
+	}
+
 	if resp.StatusCode != http.StatusCreated {
-		return "", fmt.Errorf("인증 실패: HTTP %d", resp.StatusCode)
+		return "", time.Time{}, fmt.Errorf("인증 실패: HTTP %d", resp.StatusCode)
 	}
 
 	token := resp.Header.Get("X-Subject-Token")
 	if token == "" {
-		return "", fmt.Errorf("인증 토큰을 받지 못했습니다")
+		return "", time.Time{}, fmt.Errorf("인증 토큰을 받지 못했습니다")
 	}
 
-	return token, nil
-}
-
-func (s *OpenStackService) GetAllVMInstances(participant *models.Participant) ([]VMInstance, error) {
-    token, err := s.GetAuthToken(participant)
-    if err != nil {
-        return nil, fmt.Errorf("인증 실패: %v", err)
-    }
+	var tokenResp AuthTokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", time.Time{}, fmt.Errorf("인증 응답 파싱 실패: %v", err)
+	}
 
-	url := fmt.Sprintf("%s/compute/v2.1/servers/detail", participant.OpenStackEndpoint)
-    
-    req, err := http.NewRequest("GET", url, nil)
-    if err != nil {
-        return nil, fmt.Errorf("HTTP 요청 생성 실패: %v", err)
-    }
+	return token, tokenResp.Token.ExpiresAt, nil
+}
 
-    req.Header.Set("X-Auth-Token", token)
-    req.Header.Set("Accept", "application/json")
+// GetAllVMInstances는 participant의 모든 VM 인스턴스를 조회합니다. flavor
+// 상세 정보는 먼저 PrefetchFlavors로 예열된 flavorCache에서 찾고, 캐시에 없는
+// flavor만 바운드된 errgroup으로 병렬 조회합니다. 일부 flavor 조회가 실패해도
+// "Unknown"으로 조용히 대체하지 않고 flavorErrors에 인스턴스 ID별 에러를 담아
+// 반환하므로, 호출부가 재시도 여부를 판단할 수 있습니다.
+func (s *OpenStackService) GetAllVMInstances(participant *models.Participant) (instances []VMInstance, flavorErrors map[string]error, err error) {
+    url := fmt.Sprintf("%s/compute/v2.1/servers/detail", participant.OpenStackEndpoint)
 
-    resp, err := s.client.Do(req)
+    resp, err := s.doAuthenticated(participant, "VM 목록 조회", func(token string) (*http.Request, error) {
+        req, err := newOpenStackRequest(participant, "GET", url, nil)
+        if err != nil {
+            return nil, fmt.Errorf("HTTP 요청 생성 실패: %v", err)
+        }
+        req.Header.Set("X-Auth-Token", token)
+        req.Header.Set("Accept", "application/json")
+        return req, nil
+    })
     if err != nil {
-        return nil, fmt.Errorf("VM 목록 조회 실패: %v", err)
+        return nil, nil, err
     }
     defer resp.Body.Close()
 
     body, err := io.ReadAll(resp.Body)
     if err != nil {
-        return nil, fmt.Errorf("응답 읽기 실패: %v", err)
+        return nil, nil, fmt.Errorf("응답 읽기 실패: %v", err)
     }
 
     if resp.StatusCode != http.StatusOK {
-        return nil, fmt.Errorf("VM 목록 조회 실패: HTTP %d, 응답: %s", resp.StatusCode, string(body))
+        return nil, nil, fmt.Errorf("VM 목록 조회 실패: HTTP %d, 응답: %s", resp.StatusCode, string(body))
     }
 
     // 먼저 기본 VM 정보를 파싱
@@ -201,22 +428,70 @@ func (s *OpenStackService) GetAllVMInstances(participant *models.Participant) ([
     }
 
     if err := json.Unmarshal(body, &basicResponse); err != nil {
-        return nil, fmt.Errorf("응답 파싱 실패: %v, 응답 내용: %s", err, string(body))
+        return nil, nil, fmt.Errorf("응답 파싱 실패: %v, 응답 내용: %s", err, string(body))
     }
 
-    // 각 VM에 대해 flavor 상세 정보를 가져와서 완전한 VMInstance 생성
-    var vmInstances []VMInstance
+    // flavor 상세 정보를 가능한 한 flavorCache에서 채우고, 없는 것만 모아
+    // 병렬로 조회합니다. PrefetchFlavors 실패는 치명적이지 않으므로 무시하고
+    // 캐시 미스마다 개별 조회로 대체합니다.
+    _ = s.PrefetchFlavors(participant)
+
+    flavors := make(map[string]*FlavorDetails, len(basicResponse.Servers))
     for _, server := range basicResponse.Servers {
-        flavorDetails, err := s.GetFlavorDetails(participant, token, server.Flavor.ID)
-        if err != nil {
-            // Flavor 정보를 가져오지 못한 경우 기본값으로 설정
-            flavorDetails = &FlavorDetails{
-                ID:    server.Flavor.ID,
-                Name:  "Unknown",
-                VCPUs: 0,
-                RAM:   0,
-                Disk:  0,
+        if _, ok := flavors[server.Flavor.ID]; ok {
+            continue
+        }
+        if cached, ok := s.flavorCache.get(participant.OpenStackEndpoint, server.Flavor.ID); ok {
+            flavors[server.Flavor.ID] = cached
+        } else {
+            flavors[server.Flavor.ID] = nil
+        }
+    }
+
+    var flavorsMu sync.Mutex
+    group, groupCtx := errgroup.WithContext(context.Background())
+    group.SetLimit(s.flavorFetchConcurrency)
+
+    for flavorID, details := range flavors {
+        if details != nil {
+            continue
+        }
+
+        flavorID := flavorID
+        group.Go(func() error {
+            if groupCtx.Err() != nil {
+                return groupCtx.Err()
+            }
+
+            fetched, err := s.GetFlavorDetails(participant, flavorID)
+            if err != nil {
+                return fmt.Errorf("flavor %s 조회 실패: %v", flavorID, err)
+            }
+
+            s.flavorCache.set(participant.OpenStackEndpoint, flavorID, *fetched)
+
+            flavorsMu.Lock()
+            flavors[flavorID] = fetched
+            flavorsMu.Unlock()
+
+            return nil
+        })
+    }
+    // 첫 flavor 조회 실패가 group의 에러가 되어 groupCtx를 취소하므로, 그때까지
+    // 시작하지 않은 나머지 flavor 조회는 건너뜁니다. 실패했거나 건너뛴 flavor는
+    // flavors에 nil로 남아 아래에서 인스턴스별로 flavorErrors에 기록되므로,
+    // group.Wait()의 에러는 무시합니다.
+    _ = group.Wait()
+
+    vmInstances := make([]VMInstance, 0, len(basicResponse.Servers))
+    for _, server := range basicResponse.Servers {
+        flavorDetails := flavors[server.Flavor.ID]
+        if flavorDetails == nil {
+            if flavorErrors == nil {
+                flavorErrors = make(map[string]error)
             }
+            flavorErrors[server.ID] = fmt.Errorf("flavor %s 정보를 가져오지 못했습니다", server.Flavor.ID)
+            flavorDetails = &FlavorDetails{ID: server.Flavor.ID}
         }
 
         vmInstance := VMInstance{
@@ -233,27 +508,27 @@ func (s *OpenStackService) GetAllVMInstances(participant *models.Participant) ([
         vmInstances = append(vmInstances, vmInstance)
     }
 
-    return vmInstances, nil
+    return vmInstances, flavorErrors, nil
 }
 
-
 // VM 인스턴스 정보 조회
-func (s *OpenStackService) GetVMInstance(vm *models.VirtualMachine, participant *models.Participant, token string) (*VMInstance, error) {
+func (s *OpenStackService) GetVMInstance(vm *models.VirtualMachine, participant *models.Participant) (*VMInstance, error) {
 	if vm.InstanceID == "" {
 		return nil, fmt.Errorf("VM 인스턴스 ID가 설정되지 않았습니다")
 	}
 
 	url := fmt.Sprintf("%s/compute/v2.1/servers/%s", participant.OpenStackEndpoint, vm.InstanceID)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("HTTP 요청 생성 실패: %v", err)
-	}
 
-	req.Header.Set("X-Auth-Token", token)
-
-	resp, err := s.client.Do(req)
+	resp, err := s.doAuthenticated(participant, "VM 정보 조회", func(token string) (*http.Request, error) {
+		req, err := newOpenStackRequest(participant, "GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("HTTP 요청 생성 실패: %v", err)
+		}
+		req.Header.Set("X-Auth-Token", token)
+		return req, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("VM 정보 조회 실패: %v", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
@@ -290,7 +565,7 @@ func (s *OpenStackService) GetVMInstance(vm *models.VirtualMachine, participant
 	}
 
 	// Flavor 상세 정보 조회
-	flavorDetails, err := s.GetFlavorDetails(participant, token, basicResponse.Server.Flavor.ID)
+	flavorDetails, err := s.GetFlavorDetails(participant, basicResponse.Server.Flavor.ID)
 	if err != nil {
 		// Flavor 정보를 가져오지 못한 경우 기본값으로 설정
 		flavorDetails = &FlavorDetails{
@@ -319,7 +594,7 @@ func (s *OpenStackService) GetVMInstance(vm *models.VirtualMachine, participant
 // VM 목록 조회
 func (s *OpenStackService) ListVMInstances(participant *models.Participant, token string) ([]VMInstance, error) {
 	url := fmt.Sprintf("%s/v2.1/servers/detail", participant.OpenStackEndpoint)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := newOpenStackRequest(participant, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP 요청 생성 실패: %v", err)
 	}
@@ -352,28 +627,14 @@ func (s *OpenStackService) ListVMInstances(participant *models.Participant, toke
 
 // MonitorSpecificVM은 특정 VM의 모니터링 정보를 조회합니다 (더 이상 DB에 저장하지 않음)
 func (s *OpenStackService) MonitorSpecificVM(participant *models.Participant, vm *models.VirtualMachine) (*models.VMMonitoringInfo, error) {
-	// 실제 환경에서는 OpenStack의 telemetry 서비스(Ceilometer)나 
-	// Prometheus 메트릭을 통해 실제 모니터링 데이터를 수집해야 합니다.
-	// 여기서는 시뮬레이션 데이터를 반환합니다.
-	return s.GetVMMonitoringInfo(vm.InstanceID)
+	return s.GetVMMonitoringInfo(participant, vm.InstanceID)
 }
 
 // VM 헬스체크 수행
 func (s *OpenStackService) HealthCheckSpecificVM(participant *models.Participant, vm *models.VirtualMachine) (*VMHealthCheckResult, error) {
 	startTime := time.Now()
-	
-	token, err := s.GetAuthToken(participant)
-	if err != nil {
-		return &VMHealthCheckResult{
-			Healthy:      false,
-			Status:       "ERROR",
-			Message:      fmt.Sprintf("인증 실패: %v", err),
-			CheckedAt:    time.Now(),
-			ResponseTime: time.Since(startTime).Milliseconds(),
-		}, nil
-	}
 
-	instance, err := s.GetVMInstance(vm, participant, token)
+	instance, err := s.GetVMInstance(vm, participant)
 	if err != nil {
 		return &VMHealthCheckResult{
 			Healthy:      false,
@@ -402,43 +663,27 @@ func (s *OpenStackService) HealthCheckSpecificVM(participant *models.Participant
 }
 
 // 연합학습 작업 할당 (특정 VirtualMachine 인스턴스 기반)
+// taskID만 알고 있는 기존 호출부를 위한 얇은 래퍼이며, 실제 작업 할당은
+// AssignFederatedLearningTask(SSH 기반)에 위임합니다.
 func (s *OpenStackService) AssignFederatedLearningTaskSpecific(participant *models.Participant, vm *models.VirtualMachine, taskID string) error {
-	// 현재 VM 상태 확인
-	token, err := s.GetAuthToken(participant)
-	if err != nil {
-		return fmt.Errorf("인증 실패: %v", err)
-	}
-
-	instance, err := s.GetVMInstance(vm, participant, token)
-	if err != nil {
-		return fmt.Errorf("VM 상태 확인 실패: %v", err)
-	}
-
-	if instance.Status != "ACTIVE" {
-		return fmt.Errorf("VM이 활성 상태가 아닙니다: %s", instance.Status)
-	}
-
-	// 실제 환경에서는 VM에 SSH 연결하거나 에이전트를 통해 
-	// 연합학습 작업을 할당하고 실행합니다.
-	// 여기서는 시뮬레이션합니다.
-	
-	return nil
+	return s.AssignFederatedLearningTask(participant, vm, TrainingJobManifest{TaskID: taskID})
 }
 
 // GetFlavorDetails는 특정 flavor의 상세 정보를 조회합니다
-func (s *OpenStackService) GetFlavorDetails(participant *models.Participant, token string, flavorID string) (*FlavorDetails, error) {
+func (s *OpenStackService) GetFlavorDetails(participant *models.Participant, flavorID string) (*FlavorDetails, error) {
 	url := fmt.Sprintf("%s/compute/v2.1/flavors/%s", participant.OpenStackEndpoint, flavorID)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("HTTP 요청 생성 실패: %v", err)
-	}
 
-	req.Header.Set("X-Auth-Token", token)
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := s.client.Do(req)
+	resp, err := s.doAuthenticated(participant, "Flavor 정보 조회", func(token string) (*http.Request, error) {
+		req, err := newOpenStackRequest(participant, "GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("HTTP 요청 생성 실패: %v", err)
+		}
+		req.Header.Set("X-Auth-Token", token)
+		req.Header.Set("Accept", "application/json")
+		return req, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("Flavor 정보 조회 실패: %v", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
@@ -462,60 +707,29 @@ func (s *OpenStackService) GetFlavorDetails(participant *models.Participant, tok
 	return &response.Flavor, nil
 }
 
-// SyncVMsFromOpenStack은 OpenStack에서 VM 정보를 동기화하여 DB에 저장합니다
-func (s *OpenStackService) SyncVMsFromOpenStack(participant *models.Participant) ([]models.VirtualMachine, error) {
-	openStackVMs, err := s.GetAllVMInstances(participant)
-	if err != nil {
-		return nil, fmt.Errorf("OpenStack VM 목록 조회 실패: %v", err)
-	}
-
-	var syncedVMs []models.VirtualMachine
-	
-	for _, osVM := range openStackVMs {
-		// IP 주소 직렬화
-		ipAddressesJSON, _ := json.Marshal(osVM.Addresses)
-		
-		// VM 정보 구성 (DB에 저장할 안정적인 정보만)
-		vm := models.VirtualMachine{
-			InstanceID:       osVM.ID,
-			Name:            osVM.Name,
-			ParticipantID:   participant.ID,
-			Status:          osVM.Status,
-			FlavorID:        osVM.Flavor.ID,
-			FlavorName:      osVM.Flavor.Name,
-			VCPUs:          osVM.Flavor.VCPUs,
-			RAM:            osVM.Flavor.RAM,
-			Disk:           osVM.Flavor.Disk,
-			IPAddresses:    string(ipAddressesJSON),
-			AvailabilityZone: osVM.AvailabilityZone,
-		}
-		
-		syncedVMs = append(syncedVMs, vm)
-	}
-	
-	return syncedVMs, nil
-}
-
 // GetVMRuntimeStatus는 실시간 VM 상태를 조회합니다 (DB에 저장하지 않음)
 func (s *OpenStackService) GetVMRuntimeStatus(participant *models.Participant, instanceID string) (*models.VMRuntimeInfo, error) {
-	token, err := s.GetAuthToken(participant)
-	if err != nil {
-		return nil, fmt.Errorf("인증 실패: %v", err)
-	}
-
 	url := fmt.Sprintf("%s/compute/v2.1/servers/%s", participant.OpenStackEndpoint, instanceID)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("HTTP 요청 생성 실패: %v", err)
-	}
 
-	req.Header.Set("X-Auth-Token", token)
-	resp, err := s.client.Do(req)
+	resp, err := s.doAuthenticated(participant, "VM 상태 조회", func(token string) (*http.Request, error) {
+		req, err := newOpenStackRequest(participant, "GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("HTTP 요청 생성 실패: %v", err)
+		}
+		req.Header.Set("X-Auth-Token", token)
+		return req, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("VM 상태 조회 실패: %v", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &openstackAPIError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
 	var response struct {
 		Server struct {
 			Status     string `json:"status"`
@@ -523,7 +737,6 @@ func (s *OpenStackService) GetVMRuntimeStatus(participant *models.Participant, i
 		} `json:"server"`
 	}
 
-	body, _ := io.ReadAll(resp.Body)
 	if err := json.Unmarshal(body, &response); err != nil {
 		return nil, fmt.Errorf("응답 파싱 실패: %v", err)
 	}
@@ -536,10 +749,31 @@ func (s *OpenStackService) GetVMRuntimeStatus(participant *models.Participant, i
 	}, nil
 }
 
-// GetVMMonitoringInfo는 모니터링 정보를 조회합니다 (시뮬레이션)
-func (s *OpenStackService) GetVMMonitoringInfo(instanceID string) (*models.VMMonitoringInfo, error) {
-	// 실제 환경에서는 Ceilometer, Prometheus 등에서 데이터 수집
-	// 현재는 시뮬레이션 데이터 반환
+// GetVMMonitoringInfo는 VM의 모니터링 정보를 조회합니다. monitoringPoller가
+// 백그라운드에서 주기적으로 갱신하는 캐시를 읽으며, 호출 시점에 아직 캐시된 값이
+// 없으면(첫 호출) 그 자리에서 한 번 동기 수집을 수행하고 이후 폴링을 시작합니다.
+// 매 호출마다 metrics 백엔드를 직접 두드리지 않으므로, 동일 VM을 짧은 간격으로
+// 반복 조회하더라도 Gnocchi/Prometheus 등 백엔드에는 폴링 주기만큼만 부하가
+// 갑니다.
+func (s *OpenStackService) GetVMMonitoringInfo(participant *models.Participant, instanceID string) (*models.VMMonitoringInfo, error) {
+	collect := func() (*models.VMMonitoringInfo, error) {
+		return s.collectVMMetrics(participant, instanceID)
+	}
+
+	return s.monitoringPoller.get(participant.ID, instanceID, collect)
+}
+
+// collectVMMetrics는 participant에 지정된(또는 기본) MetricsCollector로 VM
+// 모니터링 정보를 수집합니다. collector가 없거나 수집에 실패하면 시뮬레이션
+// 값으로 대체합니다.
+func (s *OpenStackService) collectVMMetrics(participant *models.Participant, instanceID string) (*models.VMMonitoringInfo, error) {
+	if collector := s.collectorFor(participant); collector != nil {
+		info, err := collector.CollectVMMetrics(participant, instanceID)
+		if err == nil {
+			return info, nil
+		}
+	}
+
 	return &models.VMMonitoringInfo{
 		InstanceID:      instanceID,
 		CPUUsage:        75.5,
@@ -0,0 +1,305 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Mungge/Fleecy-Cloud/models"
+)
+
+// serverActionRequest는 OpenStack 서버 액션(reboot/resize/confirmResize/revertResize
+// 등) 호출에 사용하는 요청 바디를 감쌉니다.
+type serverActionRequest map[string]interface{}
+
+// openstackAPIError는 OpenStack API가 비정상 상태 코드를 반환했을 때 상태 코드를
+// 보존해 상위 호출부가 404(NotFound) 같은 특정 상태를 구분할 수 있게 합니다.
+type openstackAPIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *openstackAPIError) Error() string {
+	return fmt.Sprintf("HTTP %d, 응답: %s", e.StatusCode, e.Body)
+}
+
+// isNotFoundError는 err가 OpenStack의 404 응답을 나타내는지 확인합니다.
+func isNotFoundError(err error) bool {
+	var apiErr *openstackAPIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// doServerAction은 /compute/v2.1/servers/{id}/action 엔드포인트에 POST합니다.
+func (s *OpenStackService) doServerAction(participant *models.Participant, instanceID string, action serverActionRequest) error {
+	jsonData, err := json.Marshal(action)
+	if err != nil {
+		return fmt.Errorf("요청 생성 실패: %v", err)
+	}
+
+	url := fmt.Sprintf("%s/compute/v2.1/servers/%s/action", participant.OpenStackEndpoint, instanceID)
+
+	resp, err := s.doAuthenticated(participant, "서버 액션 요청", func(token string) (*http.Request, error) {
+		req, err := newOpenStackRequest(participant, "POST", url, bytes.NewBuffer(jsonData))
+		if err != nil {
+			return nil, fmt.Errorf("HTTP 요청 생성 실패: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Auth-Token", token)
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("서버 액션 요청 실패: HTTP %d, 응답: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// CreateVMInstance는 주어진 스펙으로 새 VM 인스턴스를 생성합니다. cloud-init
+// user_data를 통해 연합학습 에이전트를 부트스트랩할 수 있습니다.
+func (s *OpenStackService) CreateVMInstance(participant *models.Participant, spec VMCreateSpec) (*VMInstance, error) {
+	createReq := struct {
+		Server struct {
+			Name      string `json:"name"`
+			ImageRef  string `json:"imageRef"`
+			FlavorRef string `json:"flavorRef"`
+			Networks  []struct {
+				UUID string `json:"uuid"`
+			} `json:"networks,omitempty"`
+			KeyName  string `json:"key_name,omitempty"`
+			UserData string `json:"user_data,omitempty"`
+		} `json:"server"`
+	}{}
+
+	createReq.Server.Name = spec.Name
+	createReq.Server.ImageRef = spec.ImageID
+	createReq.Server.FlavorRef = spec.FlavorID
+	createReq.Server.KeyName = spec.KeyName
+	createReq.Server.UserData = spec.UserData
+	if spec.Network != "" {
+		createReq.Server.Networks = []struct {
+			UUID string `json:"uuid"`
+		}{{UUID: spec.Network}}
+	}
+
+	jsonData, err := json.Marshal(createReq)
+	if err != nil {
+		return nil, fmt.Errorf("요청 생성 실패: %v", err)
+	}
+
+	url := fmt.Sprintf("%s/compute/v2.1/servers", participant.OpenStackEndpoint)
+
+	resp, err := s.doAuthenticated(participant, "VM 생성 요청", func(token string) (*http.Request, error) {
+		req, err := newOpenStackRequest(participant, "POST", url, bytes.NewBuffer(jsonData))
+		if err != nil {
+			return nil, fmt.Errorf("HTTP 요청 생성 실패: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Auth-Token", token)
+		return req, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("응답 읽기 실패: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("VM 생성 실패: HTTP %d, 응답: %s", resp.StatusCode, string(body))
+	}
+
+	var created struct {
+		Server struct {
+			ID string `json:"id"`
+		} `json:"server"`
+	}
+
+	if err := json.Unmarshal(body, &created); err != nil {
+		return nil, fmt.Errorf("응답 파싱 실패: %v", err)
+	}
+
+	return &VMInstance{
+		ID:     created.Server.ID,
+		Name:   spec.Name,
+		Status: "BUILD",
+	}, nil
+}
+
+// CreateAndSyncVMInstance는 VM을 생성하고 ACTIVE 상태가 될 때까지 기다린 뒤,
+// SyncVMsForParticipant가 반환하는 것과 동일한 형태의 models.VirtualMachine으로
+// 변환해 반환합니다. 호출부는 이 결과를 그대로 영속화하면 됩니다.
+func (s *OpenStackService) CreateAndSyncVMInstance(participant *models.Participant, spec VMCreateSpec) (*models.VirtualMachine, error) {
+	created, err := s.CreateVMInstance(participant, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.WaitForStatus(participant, created.ID, "ACTIVE", 5*time.Minute); err != nil {
+		return nil, fmt.Errorf("VM 생성 후 상태 대기 실패: %v", err)
+	}
+
+	instance, err := s.GetVMInstance(&models.VirtualMachine{InstanceID: created.ID}, participant)
+	if err != nil {
+		return nil, fmt.Errorf("VM 조회 실패: %v", err)
+	}
+
+	ipAddressesJSON, _ := json.Marshal(instance.Addresses)
+
+	return &models.VirtualMachine{
+		InstanceID:       instance.ID,
+		Name:             instance.Name,
+		ParticipantID:    participant.ID,
+		Status:           instance.Status,
+		FlavorID:         instance.Flavor.ID,
+		FlavorName:       instance.Flavor.Name,
+		VCPUs:            instance.Flavor.VCPUs,
+		RAM:              instance.Flavor.RAM,
+		Disk:             instance.Flavor.Disk,
+		IPAddresses:      string(ipAddressesJSON),
+		AvailabilityZone: instance.AvailabilityZone,
+	}, nil
+}
+
+// DeleteVMInstance는 VM 인스턴스를 삭제합니다.
+func (s *OpenStackService) DeleteVMInstance(participant *models.Participant, instanceID string) error {
+	url := fmt.Sprintf("%s/compute/v2.1/servers/%s", participant.OpenStackEndpoint, instanceID)
+
+	resp, err := s.doAuthenticated(participant, "VM 삭제 요청", func(token string) (*http.Request, error) {
+		req, err := newOpenStackRequest(participant, "DELETE", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("HTTP 요청 생성 실패: %v", err)
+		}
+		req.Header.Set("X-Auth-Token", token)
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return &openstackAPIError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	s.hostKeys.forgetHostKey(instanceID)
+
+	return nil
+}
+
+// RemoveVMInstance는 docker-machine의 Remove와 유사하게, 실행 중인 VM은 먼저
+// 정지시킨 뒤 삭제합니다. 인스턴스가 이미 존재하지 않는 경우(404)는 에러로
+// 취급하지 않습니다.
+func (s *OpenStackService) RemoveVMInstance(participant *models.Participant, instanceID string) error {
+	status, err := s.GetVMRuntimeStatus(participant, instanceID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return fmt.Errorf("상태 조회 실패: %v", err)
+	}
+
+	if status.Status == "ACTIVE" {
+		if err := s.StopVM(participant, instanceID); err != nil {
+			return fmt.Errorf("VM 정지 실패: %v", err)
+		}
+
+		if err := s.WaitForStatus(participant, instanceID, "SHUTOFF", 2*time.Minute); err != nil {
+			return fmt.Errorf("VM 정지 대기 실패: %v", err)
+		}
+	}
+
+	if err := s.DeleteVMInstance(participant, instanceID); err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return fmt.Errorf("VM 삭제 실패: %v", err)
+	}
+
+	return nil
+}
+
+// StartVM은 정지된 VM 인스턴스를 시작합니다.
+func (s *OpenStackService) StartVM(participant *models.Participant, instanceID string) error {
+	return s.doServerAction(participant, instanceID, serverActionRequest{"os-start": nil})
+}
+
+// StopVM은 실행 중인 VM 인스턴스를 정지합니다.
+func (s *OpenStackService) StopVM(participant *models.Participant, instanceID string) error {
+	return s.doServerAction(participant, instanceID, serverActionRequest{"os-stop": nil})
+}
+
+// RebootVM은 VM 인스턴스를 재부팅합니다. hard가 true이면 HARD 리부팅을 수행합니다.
+func (s *OpenStackService) RebootVM(participant *models.Participant, instanceID string, hard bool) error {
+	rebootType := "SOFT"
+	if hard {
+		rebootType = "HARD"
+	}
+
+	return s.doServerAction(participant, instanceID, serverActionRequest{
+		"reboot": map[string]string{"type": rebootType},
+	})
+}
+
+// ResizeVM은 VM 인스턴스의 flavor를 변경합니다. 리사이즈 요청 후
+// WaitForStatus(instanceID, "VERIFY_RESIZE", ...)로 완료를 기다린 뒤
+// ConfirmResizeVM 또는 RevertResizeVM을 호출해야 합니다.
+func (s *OpenStackService) ResizeVM(participant *models.Participant, instanceID, flavorID string) error {
+	return s.doServerAction(participant, instanceID, serverActionRequest{
+		"resize": map[string]string{"flavorRef": flavorID},
+	})
+}
+
+// ConfirmResizeVM은 ResizeVM으로 시작한 리사이즈를 확정합니다.
+func (s *OpenStackService) ConfirmResizeVM(participant *models.Participant, instanceID string) error {
+	return s.doServerAction(participant, instanceID, serverActionRequest{"confirmResize": nil})
+}
+
+// RevertResizeVM은 ResizeVM으로 시작한 리사이즈를 되돌립니다.
+func (s *OpenStackService) RevertResizeVM(participant *models.Participant, instanceID string) error {
+	return s.doServerAction(participant, instanceID, serverActionRequest{"revertResize": nil})
+}
+
+// WaitForStatus는 VM 인스턴스의 상태가 target과 일치하거나 ERROR가 될 때까지
+// 지수 백오프로 폴링합니다. timeout에 도달하면 에러를 반환합니다.
+func (s *OpenStackService) WaitForStatus(participant *models.Participant, instanceID, target string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	backoff := 2 * time.Second
+
+	for {
+		status, err := s.GetVMRuntimeStatus(participant, instanceID)
+		if err != nil {
+			return fmt.Errorf("상태 조회 실패: %v", err)
+		}
+
+		if status.Status == target {
+			return nil
+		}
+
+		if status.Status == "ERROR" {
+			return fmt.Errorf("VM이 ERROR 상태가 되었습니다: %s", instanceID)
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%s 상태 대기 타임아웃: 현재 상태 %s", target, status.Status)
+		}
+
+		time.Sleep(backoff)
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}